@@ -0,0 +1,303 @@
+package fortigate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/nonsugar-go/tomato-conv/internal/sheetname"
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// Config is a parsed FortiOS configuration
+type Config struct {
+	Version string // #config-version
+	Header  map[string]string
+	Root    *Node
+	Global  *Node          // config global, or Root without VDOMs
+	VDOM    []*VDOM        // config vdom>edit
+	sheets  *sheetname.Set // sheet names used in the workbook
+}
+
+// parseConfig reads and parses a FortiOS configuration file.
+func parseConfig(inFile string) (*Config, error) {
+	data, err := os.ReadFile(inFile)
+	if err != nil {
+		return nil, err
+	}
+	return newConfig(string(data))
+}
+
+// newConfig parses a FortiOS configuration.
+func newConfig(data string) (*Config, error) {
+	root, comments, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	config := Config{Header: map[string]string{}, Root: root}
+	for _, c := range comments {
+		// #config-version=FGT60F-7.2.5-FW-build1517-230606:opmode=0:vdom=0:user=admin
+		for _, kv := range strings.Split(c, ":") {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				config.Header[k] = v
+			}
+		}
+	}
+	config.Version = config.Header["config-version"]
+	config.splitVDOM()
+	return &config, nil
+}
+
+// sheetName returns a global sheet name, which is unique in the workbook.
+func (c *Config) sheetName(name string) string {
+	return c.sheets.Name("", name)
+}
+
+// redact hides a secret such as a password or a pre-shared key.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "<REDACTED>"
+}
+
+// outputConfigBlocks() is the list of config blocks output process.
+func outputConfigBlocks(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("設定一覧")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputConfigBlocks: %w", err)
+	}
+	xl.SetActiveSheet()
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"設定ブロック", 40}, {"エントリ数", 10}, {"行", 8},
+	}); err != nil {
+		return fmt.Errorf("outputConfigBlocks: %w", err)
+	}
+	r := 0
+	var walk func(prefix string, n *Node) error
+	walk = func(prefix string, n *Node) error {
+		for _, c := range n.Config {
+			r++
+			name := strings.TrimSpace(prefix + " " + c.Name)
+			err := xl.SetRow(&[]any{r, name, len(c.Edit), c.Line})
+			if err != nil {
+				return err
+			}
+			// config global / config vdom
+			if n == config.Root && (c.Name == "global" || c.Name == "vdom") {
+				if err := walk(name, c); err != nil {
+					return err
+				}
+				for _, e := range c.Edit {
+					if err := walk(name+" "+e.Name, e); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk("", config.Root); err != nil {
+		return fmt.Errorf("outputConfigBlocks: %w", err)
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputConfigBlocks: %w", err)
+	}
+	return nil
+}
+
+// writeExcel outputs parameter sheets to Excel.
+func writeExcel(outFile string, config *Config) error {
+	xl, err := excel.New(outFile)
+	if err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+	config.sheets = &sheetname.Set{}
+	for _, vdom := range config.VDOM {
+		vdom.sheets = config.sheets
+	}
+	defer func() {
+		if err := xl.Close(); err != nil {
+			log.Errorf("WriteExcel: %v", err)
+		}
+	}()
+
+	// config blocks
+	if err := outputConfigBlocks(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// config system interface
+	if err := outputInterface(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// config system global, dns, ntp, snmp sysinfo, log syslogd setting
+	if err := outputSystemSetting(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// config system admin
+	if err := outputAdmin(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// config system accprofile
+	if err := outputAccprofile(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// config system snmp community, user
+	if err := outputSNMP(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// multi-VDOM
+	if config.Global != config.Root {
+		// config vdom
+		if err := outputVDOM(xl, config); err != nil {
+			return fmt.Errorf("WriteExcel: %w", err)
+		}
+
+		// config system vdom-link
+		if err := outputVDOMLink(xl, config); err != nil {
+			return fmt.Errorf("WriteExcel: %w", err)
+		}
+	}
+
+	// config vdom>edit
+	for _, vdom := range config.VDOM {
+		if err := writeVDOM(xl, vdom); err != nil {
+			return fmt.Errorf("WriteExcel: %w", err)
+		}
+	}
+
+	if err := xl.SaveAndClose(); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+	return nil
+}
+
+// writeVDOM outputs parameter sheets of the VDOM.
+func writeVDOM(xl *excel.Excel, vdom *VDOM) error {
+	// config system zone
+	if err := outputZone(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config router static, static6
+	if err := outputRouterStatic(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config router policy
+	if err := outputRouterPolicy(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config router bgp
+	if err := outputRouterBGP(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config router ospf
+	if err := outputRouterOSPF(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall address
+	if err := outputAddress(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall addrgrp
+	if err := outputAddrgrp(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall address6
+	if err := outputAddress6(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall addrgrp6
+	if err := outputAddrgrp6(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall service custom
+	if err := outputServiceCustom(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall service group
+	if err := outputServiceGroup(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall service category
+	if err := outputServiceCategory(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall vip
+	if err := outputVIP(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall vipgrp
+	if err := outputVIPGroup(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall ippool
+	if err := outputIPPool(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall central-snat-map
+	if err := outputCentralSNAT(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config vpn ipsec phase1-interface
+	if err := outputPhase1(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config vpn ipsec phase2-interface
+	if err := outputPhase2(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+
+	// config firewall policy
+	if err := outputPolicy(xl, vdom); err != nil {
+		return fmt.Errorf("writeVDOM: %w", err)
+	}
+	return nil
+}
+
+// ConvertFGTConfig converts a FortiGate config to a parameter sheet
+func ConvertFGTConfig(inFile, outFile string) error {
+	log.Infof("input file: %s\n", inFile)
+	log.Infof("output file: %s\n", outFile)
+	config, err := parseConfig(inFile)
+	if err != nil {
+		return fmt.Errorf("ConvertFGTConfig: %w", err)
+	}
+	log.Infof("The config-version of the analyzed config is %s", config.Version)
+	_, err = os.Stat(outFile)
+	if !os.IsNotExist(err) {
+		log.Infof("delete the file: %s\n", outFile)
+		err := os.Remove(outFile)
+		if err != nil {
+			return fmt.Errorf("ConvertFGTConfig: %w", err)
+		}
+	}
+	if err = writeExcel(outFile, config); err != nil {
+		return fmt.Errorf("ConvertFGTConfig: %w", err)
+	}
+	log.Infof("out put the excel file: %s\n", outFile)
+	return nil
+}
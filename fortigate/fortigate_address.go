@@ -0,0 +1,167 @@
+package fortigate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// cidr converts "address netmask" to "address/prefix-length".
+func cidr(v []string) string {
+	if len(v) != 2 {
+		return strings.Join(v, " ")
+	}
+	mask := net.ParseIP(v[1]).To4()
+	if mask == nil {
+		return strings.Join(v, " ")
+	}
+	ones, bits := net.IPMask(mask).Size()
+	if bits == 0 {
+		return strings.Join(v, " ")
+	}
+	return fmt.Sprintf("%s/%d", v[0], ones)
+}
+
+// addressValue returns the type and the value of address or address6.
+func addressValue(e *Node, ipv6 bool) (string, string) {
+	def := "ipmask"
+	if ipv6 {
+		def = "ipprefix"
+	}
+	typ := e.Value("type", def)
+	switch typ {
+	case "ipmask", "interface-subnet":
+		return typ, cidr(strings.Fields(e.Value("subnet", "0.0.0.0 0.0.0.0")))
+	case "ipprefix":
+		return typ, e.Value("ip6", "::/0")
+	case "iprange":
+		return typ, e.Get("start-ip") + "-" + e.Get("end-ip")
+	case "fqdn":
+		return typ, e.Get("fqdn")
+	case "geography":
+		return typ, e.Get("country")
+	case "wildcard":
+		return typ, e.Get("wildcard")
+	case "wildcard-fqdn":
+		return typ, e.Get("wildcard-fqdn")
+	case "dynamic":
+		return typ, strings.TrimSpace(e.Get("sdn") + " " + e.Get("filter"))
+	case "mac":
+		return typ, e.Get("macaddr")
+	case "template":
+		return typ, e.Get("template")
+	}
+	return typ, ""
+}
+
+// memberOf returns the names of the groups which have the member.
+func memberOf(groups *Node, name string) []string {
+	var s []string
+	for _, g := range groups.Entries() {
+		for _, m := range g.List("member") {
+			if m == name {
+				s = append(s, g.Name)
+				break
+			}
+		}
+	}
+	return s
+}
+
+// outputAddress() is <config firewall address> output process.
+func outputAddress(xl *excel.Excel, vdom *VDOM) error {
+	if err := writeAddress(xl, vdom.sheetName("アドレス"), vdom.Block("firewall address"),
+		vdom.Block("firewall addrgrp"), false); err != nil {
+		return fmt.Errorf("outputAddress: %w", err)
+	}
+	return nil
+}
+
+// outputAddress6() is <config firewall address6> output process.
+func outputAddress6(xl *excel.Excel, vdom *VDOM) error {
+	if err := writeAddress(xl, vdom.sheetName("IPv6アドレス"), vdom.Block("firewall address6"),
+		vdom.Block("firewall addrgrp6"), true); err != nil {
+		return fmt.Errorf("outputAddress6: %w", err)
+	}
+	return nil
+}
+
+// writeAddress writes the rows of address and address6 sheets.
+func writeAddress(xl *excel.Excel, sheet string, addrs, groups *Node, ipv6 bool) error {
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("writeAddress: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 12}, {"アドレス", 30},
+		{"インターフェイス", 12}, {"色", 4}, {"所属グループ", 20},
+		{"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("writeAddress: %w", err)
+	}
+	for i, e := range addrs.Entries() {
+		typ, value := addressValue(e, ipv6)
+		intf := e.Get("associated-interface")
+		if typ == "interface-subnet" {
+			intf = e.Get("interface")
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, typ, value, intf, e.Value("color", "0"),
+			memberOf(groups, e.Name), e.Get("comment")})
+		if err != nil {
+			return fmt.Errorf("writeAddress: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("writeAddress: %w", err)
+	}
+	return nil
+}
+
+// outputAddrgrp() is <config firewall addrgrp> output process.
+func outputAddrgrp(xl *excel.Excel, vdom *VDOM) error {
+	if err := writeAddrgrp(xl, vdom.sheetName("アドレスグループ"),
+		vdom.Block("firewall addrgrp")); err != nil {
+		return fmt.Errorf("outputAddrgrp: %w", err)
+	}
+	return nil
+}
+
+// outputAddrgrp6() is <config firewall addrgrp6> output process.
+func outputAddrgrp6(xl *excel.Excel, vdom *VDOM) error {
+	if err := writeAddrgrp(xl, vdom.sheetName("IPv6アドレスグループ"),
+		vdom.Block("firewall addrgrp6")); err != nil {
+		return fmt.Errorf("outputAddrgrp6: %w", err)
+	}
+	return nil
+}
+
+// writeAddrgrp writes the rows of addrgrp and addrgrp6 sheets.
+func writeAddrgrp(xl *excel.Excel, sheet string, groups *Node) error {
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("writeAddrgrp: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"メンバー", 60}, {"除外メンバー", 30},
+		{"色", 4}, {"所属グループ", 20}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("writeAddrgrp: %w", err)
+	}
+	for i, e := range groups.Entries() {
+		var exclude []string
+		if e.Get("exclude") == "enable" {
+			exclude = e.List("exclude-member")
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.List("member"), exclude, e.Value("color", "0"),
+			memberOf(groups, e.Name), e.Get("comment")})
+		if err != nil {
+			return fmt.Errorf("writeAddrgrp: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("writeAddrgrp: %w", err)
+	}
+	return nil
+}
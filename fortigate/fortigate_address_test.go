@@ -0,0 +1,52 @@
+package fortigate
+
+import "testing"
+
+func TestAddressValue(t *testing.T) {
+	config, err := newConfig(`config firewall address
+    edit "all"
+    next
+    edit "net"
+        set subnet 10.0.0.0 255.255.255.0
+    next
+    edit "range"
+        set type iprange
+        set start-ip 10.0.0.1
+        set end-ip 10.0.0.9
+    next
+    edit "fqdn"
+        set type fqdn
+        set fqdn "example.com"
+    next
+end
+config firewall address6
+    edit "all"
+    next
+    edit "net6"
+        set ip6 2001:db8::/32
+    next
+end
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		block, name string
+		ipv6        bool
+		typ, value  string
+	}{
+		{"firewall address", "all", false, "ipmask", "0.0.0.0/0"},
+		{"firewall address", "net", false, "ipmask", "10.0.0.0/24"},
+		{"firewall address", "range", false, "iprange", "10.0.0.1-10.0.0.9"},
+		{"firewall address", "fqdn", false, "fqdn", "example.com"},
+		{"firewall address6", "all", true, "ipprefix", "::/0"},
+		{"firewall address6", "net6", true, "ipprefix", "2001:db8::/32"},
+	}
+	for _, tt := range tests {
+		e := config.Root.Block(tt.block).Entry(tt.name)
+		typ, value := addressValue(e, tt.ipv6)
+		if typ != tt.typ || value != tt.value {
+			t.Errorf("%s %s = %q %q, want %q %q", tt.block, tt.name, typ, value, tt.typ, tt.value)
+		}
+	}
+}
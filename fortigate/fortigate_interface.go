@@ -0,0 +1,98 @@
+package fortigate
+
+import (
+	"fmt"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// zoneOf returns the zone which has the interface.
+func zoneOf(vdom *Node, intf string) string {
+	for _, z := range vdom.Block("system zone").Entries() {
+		for _, m := range z.List("interface") {
+			if m == intf {
+				return z.Name
+			}
+		}
+	}
+	return ""
+}
+
+// secondaryIPs returns the secondary IP addresses of the interface.
+func secondaryIPs(e *Node) []string {
+	if e.Get("secondary-IP") != "enable" {
+		return nil
+	}
+	var s []string
+	for _, ip := range e.Block("secondaryip").Entries() {
+		s = append(s, cidr(ip.List("ip")))
+	}
+	return s
+}
+
+// outputInterface() is <config system interface> output process.
+func outputInterface(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("インターフェイス")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputInterface: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 16}, {"VDOM", 10}, {"タイプ", 10}, {"モード", 8},
+		{"IPアドレス", 18}, {"管理アクセス", 24}, {"親インターフェイス", 12},
+		{"VLAN ID", 6}, {"メンバー", 16}, {"LACPモード", 8}, {"役割", 8},
+		{"エイリアス", 12}, {"ゾーン", 12}, {"セカンダリIP", 18}, {"MTU", 6},
+		{"状態", 6}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputInterface: %w", err)
+	}
+	for i, e := range config.Global.Block("system interface").Entries() {
+		typ := e.Value("type", "physical")
+		lacpMode := ""
+		if typ == "aggregate" {
+			lacpMode = e.Value("lacp-mode", "active")
+		}
+		mtu := ""
+		if e.Get("mtu-override") == "enable" {
+			mtu = e.Get("mtu")
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("vdom"), typ, e.Value("mode", "static"),
+			cidr(e.List("ip")), e.List("allowaccess"), e.Get("interface"),
+			e.Get("vlanid"), e.List("member"), lacpMode,
+			e.Value("role", "undefined"), e.Get("alias"), zoneOfVDOM(config.vdom(e.Get("vdom")), e.Name),
+			secondaryIPs(e), mtu, e.Value("status", "up"), e.Get("description")})
+		if err != nil {
+			return fmt.Errorf("outputInterface: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputInterface: %w", err)
+	}
+	return nil
+}
+
+// outputZone() is <config system zone> output process.
+func outputZone(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("ゾーン")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputZone: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"インターフェイス", 30}, {"ゾーン内通信", 10},
+		{"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputZone: %w", err)
+	}
+	for i, e := range vdom.Block("system zone").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.List("interface"), e.Value("intrazone", "deny"),
+			e.Get("description")})
+		if err != nil {
+			return fmt.Errorf("outputZone: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputZone: %w", err)
+	}
+	return nil
+}
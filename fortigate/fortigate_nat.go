@@ -0,0 +1,163 @@
+package fortigate
+
+import (
+	"fmt"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// vips returns the members which are VIPs or VIP groups.
+func vips(vdom *Node, members []string) []string {
+	var s []string
+	for _, m := range members {
+		for _, block := range []string{"firewall vip", "firewall vip6", "firewall vipgrp"} {
+			if vdom.Block(block).Entry(m) != nil {
+				s = append(s, m)
+				break
+			}
+		}
+	}
+	return s
+}
+
+// realServers returns the real servers of the load-balance VIP.
+func realServers(e *Node) []string {
+	var s []string
+	for _, r := range e.Block("realservers").Entries() {
+		v := r.Get("ip")
+		if r.Has("port") {
+			v += ":" + r.Get("port")
+		}
+		if r.Has("weight") {
+			v += " weight " + r.Get("weight")
+		}
+		if r.Value("status", "active") != "active" {
+			v += " (" + r.Get("status") + ")"
+		}
+		s = append(s, v)
+	}
+	return s
+}
+
+// outputVIP() is <config firewall vip> output process.
+func outputVIP(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("バーチャルIP")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputVIP: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 12}, {"インターフェイス", 12},
+		{"外部IP", 18}, {"マップIP", 18}, {"ポート転送", 8}, {"プロトコル", 8},
+		{"外部ポート", 10}, {"マップポート", 10}, {"負荷分散方式", 10},
+		{"実サーバー", 30}, {"所属グループ", 20}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputVIP: %w", err)
+	}
+	groups := vdom.Block("firewall vipgrp")
+	for i, e := range vdom.Block("firewall vip").Entries() {
+		portforward := e.Value("portforward", "disable")
+		protocol := ""
+		if portforward == "enable" {
+			protocol = e.Value("protocol", "tcp")
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Value("type", "static-nat"), e.Get("extintf"),
+			e.Get("extip"), e.List("mappedip"), portforward, protocol,
+			e.Get("extport"), e.Get("mappedport"), e.Get("ldb-method"),
+			realServers(e), memberOf(groups, e.Name), e.Get("comment")})
+		if err != nil {
+			return fmt.Errorf("outputVIP: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputVIP: %w", err)
+	}
+	return nil
+}
+
+// outputVIPGroup() is <config firewall vipgrp> output process.
+func outputVIPGroup(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("バーチャルIPグループ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputVIPGroup: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"インターフェイス", 12}, {"メンバー", 60},
+		{"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputVIPGroup: %w", err)
+	}
+	for i, e := range vdom.Block("firewall vipgrp").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("interface"), e.List("member"),
+			e.Get("comments")})
+		if err != nil {
+			return fmt.Errorf("outputVIPGroup: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputVIPGroup: %w", err)
+	}
+	return nil
+}
+
+// outputIPPool() is <config firewall ippool> output process.
+func outputIPPool(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("IPプール")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputIPPool: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 16}, {"外部IP範囲", 30},
+		{"送信元IP範囲", 30}, {"ARP応答", 8}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputIPPool: %w", err)
+	}
+	for i, e := range vdom.Block("firewall ippool").Entries() {
+		source := ""
+		if e.Has("source-startip") {
+			source = e.Get("source-startip") + "-" + e.Get("source-endip")
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Value("type", "overload"),
+			e.Get("startip") + "-" + e.Get("endip"), source,
+			e.Value("arp-reply", "enable"), e.Get("comments")})
+		if err != nil {
+			return fmt.Errorf("outputIPPool: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputIPPool: %w", err)
+	}
+	return nil
+}
+
+// outputCentralSNAT() is <config firewall central-snat-map> output process.
+func outputCentralSNAT(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("セントラルSNAT")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputCentralSNAT: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"ID", 6}, {"送信元インターフェイス", 16},
+		{"宛先インターフェイス", 16}, {"送信元", 30}, {"宛先", 30},
+		{"プロトコル", 8}, {"送信元ポート", 10}, {"NAT", 8}, {"IPプール", 20},
+		{"NATポート", 10}, {"状態", 8}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputCentralSNAT: %w", err)
+	}
+	for i, e := range vdom.Block("firewall central-snat-map").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.List("srcintf"), e.List("dstintf"),
+			e.List("orig-addr"), e.List("dst-addr"), e.Value("protocol", "0"),
+			e.Get("orig-port"), e.Value("nat", "enable"), e.List("nat-ippool"),
+			e.Get("nat-port"), e.Value("status", "enable"), e.Get("comments")})
+		if err != nil {
+			return fmt.Errorf("outputCentralSNAT: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputCentralSNAT: %w", err)
+	}
+	return nil
+}
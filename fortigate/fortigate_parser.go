@@ -0,0 +1,251 @@
+package fortigate
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* FortiOS configuration
+ * #config-version=FGT60F-7.2.5-FW-build1517-230606:opmode=0:vdom=0:user=admin
+ * #conf_file_ver=...
+ * #buildno=1517
+ * #global_vdom=1
+ * config system global
+ *     set hostname "FGT60F"
+ * end
+ * config firewall address
+ *     edit "host1"
+ *         set subnet 192.168.1.1 255.255.255.255
+ *     next
+ * end
+ */
+
+// Node is the root, a config block or an edit entry of a FortiOS config
+type Node struct {
+	Name     string              // block path ("firewall policy") or edit key ("1")
+	Set      map[string][]string // values of set commands
+	SetOrder []string            // keys of set commands in order of appearance
+	Unset    []string            // keys of unset commands
+	Config   []*Node             // nested config blocks
+	Edit     []*Node             // edit entries
+	Line     int                 // line number of the config or edit command
+}
+
+func newNode(name string, line int) *Node {
+	return &Node{Name: name, Set: map[string][]string{}, Line: line}
+}
+
+// Block returns the nested config block with the name or nil.
+func (n *Node) Block(name string) *Node {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Config {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Entries returns the edit entries of the config block.
+func (n *Node) Entries() []*Node {
+	if n == nil {
+		return nil
+	}
+	return n.Edit
+}
+
+// Entry returns the edit entry with the name or nil.
+func (n *Node) Entry(name string) *Node {
+	for _, e := range n.Entries() {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// List returns the values of the set command.
+func (n *Node) List(key string) []string {
+	if n == nil {
+		return nil
+	}
+	return n.Set[key]
+}
+
+// Get returns the values of the set command joined by a space.
+func (n *Node) Get(key string) string {
+	return strings.Join(n.List(key), " ")
+}
+
+// Value returns the value of the set command or def when it is not set.
+func (n *Node) Value(key, def string) string {
+	if !n.Has(key) {
+		return def
+	}
+	return n.Get(key)
+}
+
+// Has reports whether the set command exists.
+func (n *Node) Has(key string) bool {
+	if n == nil {
+		return false
+	}
+	_, ok := n.Set[key]
+	return ok
+}
+
+// token is a word of a command line
+type token struct {
+	text string
+	line int
+}
+
+// tokenize splits the config into command lines. Quoted strings may
+// contain spaces and newlines, and a backslash escapes the next character.
+func tokenize(data string) (lines [][]token, comments []string, err error) {
+	var (
+		line    []token
+		buf     strings.Builder
+		inToken bool
+		quote   bool
+		escape  bool
+		lineNo  = 1
+		start   = 1
+	)
+	flush := func() {
+		if inToken {
+			line = append(line, token{buf.String(), start})
+			buf.Reset()
+			inToken = false
+		}
+	}
+	rs := []rune(strings.TrimPrefix(data, "\ufeff"))
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case escape:
+			buf.WriteRune(r)
+			escape = false
+		case r == '\\':
+			if !inToken {
+				inToken, start = true, lineNo
+			}
+			escape = true
+		case quote:
+			if r == '"' {
+				quote = false
+			} else {
+				buf.WriteRune(r)
+			}
+		case r == '"':
+			if !inToken {
+				inToken, start = true, lineNo
+			}
+			quote = true
+		case r == '#' && !inToken && len(line) == 0:
+			j := i
+			for j < len(rs) && rs[j] != '\n' {
+				j++
+			}
+			comments = append(comments, strings.TrimRight(string(rs[i+1:j]), "\r"))
+			i = j - 1
+		case r == '\n':
+			flush()
+			if len(line) > 0 {
+				lines = append(lines, line)
+				line = nil
+			}
+		case r == ' ' || r == '\t' || r == '\r':
+			flush()
+		default:
+			if !inToken {
+				inToken, start = true, lineNo
+			}
+			buf.WriteRune(r)
+		}
+		if r == '\n' {
+			lineNo++
+		}
+	}
+	if quote {
+		return nil, nil, fmt.Errorf("line %d: unterminated quoted string", start)
+	}
+	flush()
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines, comments, nil
+}
+
+// parse parses FortiOS config/edit/set/unset/next/end commands into a tree.
+func parse(data string) (*Node, []string, error) {
+	lines, comments, err := tokenize(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse: %w", err)
+	}
+	root := newNode("", 0)
+	stack := []*Node{root}
+	isEdit := []bool{false}
+	for _, line := range lines {
+		cur := stack[len(stack)-1]
+		cmd, lineNo := line[0].text, line[0].line
+		args := make([]string, 0, len(line)-1)
+		for _, t := range line[1:] {
+			args = append(args, t.text)
+		}
+		switch cmd {
+		case "config":
+			if len(args) == 0 {
+				return nil, nil, fmt.Errorf("parse: line %d: config without a name", lineNo)
+			}
+			node := newNode(strings.Join(args, " "), lineNo)
+			cur.Config = append(cur.Config, node)
+			stack, isEdit = append(stack, node), append(isEdit, false)
+		case "edit":
+			if len(args) == 0 {
+				return nil, nil, fmt.Errorf("parse: line %d: edit without a name", lineNo)
+			}
+			node := newNode(args[0], lineNo)
+			cur.Edit = append(cur.Edit, node)
+			stack, isEdit = append(stack, node), append(isEdit, true)
+		case "next":
+			if !isEdit[len(isEdit)-1] {
+				return nil, nil, fmt.Errorf("parse: line %d: next without edit", lineNo)
+			}
+			stack, isEdit = stack[:len(stack)-1], isEdit[:len(isEdit)-1]
+		case "end":
+			for len(stack) > 1 && isEdit[len(isEdit)-1] {
+				stack, isEdit = stack[:len(stack)-1], isEdit[:len(isEdit)-1]
+			}
+			if len(stack) == 1 {
+				return nil, nil, fmt.Errorf("parse: line %d: end without config", lineNo)
+			}
+			stack, isEdit = stack[:len(stack)-1], isEdit[:len(isEdit)-1]
+		case "set", "append":
+			if len(args) == 0 {
+				return nil, nil, fmt.Errorf("parse: line %d: %s without a key", lineNo, cmd)
+			}
+			key := args[0]
+			if _, ok := cur.Set[key]; !ok {
+				cur.SetOrder = append(cur.SetOrder, key)
+			}
+			if cmd == "append" {
+				cur.Set[key] = append(cur.Set[key], args[1:]...)
+			} else {
+				cur.Set[key] = args[1:]
+			}
+		case "unset":
+			if len(args) > 0 {
+				cur.Unset = append(cur.Unset, args[0])
+			}
+		default:
+			// select, unselect, delete, rename, move, ... are not used in backups
+		}
+	}
+	if len(stack) > 1 {
+		return nil, nil, fmt.Errorf("parse: unexpected end of file in %q", stack[len(stack)-1].Name)
+	}
+	return root, comments, nil
+}
@@ -0,0 +1,188 @@
+package fortigate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// dump returns the tree as "path key=[values]" lines.
+func dump(n *Node, path string) []string {
+	var s []string
+	for _, k := range n.SetOrder {
+		s = append(s, fmt.Sprintf("%s%s=%q", path, k, n.Set[k]))
+	}
+	for _, k := range n.Unset {
+		s = append(s, fmt.Sprintf("%sunset %s", path, k))
+	}
+	for _, c := range n.Config {
+		s = append(s, fmt.Sprintf("%s[%s]", path, c.Name))
+		s = append(s, dump(c, path+c.Name+"/")...)
+	}
+	for _, e := range n.Edit {
+		s = append(s, fmt.Sprintf("%s{%s}", path, e.Name))
+		s = append(s, dump(e, path+e.Name+"/")...)
+	}
+	return s
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     []string
+		comments []string
+		err      string
+	}{
+		{
+			name: "block",
+			data: "config system global\n    set hostname FGT\n    set timezone 60\nend\n",
+			want: []string{
+				`[system global]`,
+				`system global/hostname=["FGT"]`,
+				`system global/timezone=["60"]`,
+			},
+		},
+		{
+			name: "quoted and multi-value",
+			data: "config firewall policy\n  edit 1\n    set name \"allow web\"\n" +
+				"    set dstintf \"port2\" \"port3\"\n    set comments \"\"\n  next\nend\n",
+			want: []string{
+				`[firewall policy]`,
+				`firewall policy/{1}`,
+				`firewall policy/1/name=["allow web"]`,
+				`firewall policy/1/dstintf=["port2" "port3"]`,
+				`firewall policy/1/comments=[""]`,
+			},
+		},
+		{
+			name: "backslash escapes",
+			data: "config firewall address\n  edit \"a \\\"b\\\" \\\\ c\"\n" +
+				"    set comment my\\ host\n  next\nend\n",
+			want: []string{
+				`[firewall address]`,
+				`firewall address/{a "b" \ c}`,
+				`firewall address/a "b" \ c/comment=["my host"]`,
+			},
+		},
+		{
+			name: "multi-line quoted value",
+			data: "config vpn certificate local\n  edit cert\n" +
+				"    set certificate \"-----BEGIN-----\nMIIB\nend\n-----END-----\"\n" +
+				"  next\nend\n",
+			want: []string{
+				`[vpn certificate local]`,
+				`vpn certificate local/{cert}`,
+				`vpn certificate local/cert/certificate=["-----BEGIN-----\nMIIB\nend\n-----END-----"]`,
+			},
+		},
+		{
+			name: "append and unset",
+			data: "config system interface\n  edit port1\n    set allowaccess ping\n" +
+				"    append allowaccess https ssh\n    unset alias\n  next\nend\n",
+			want: []string{
+				`[system interface]`,
+				`system interface/{port1}`,
+				`system interface/port1/allowaccess=["ping" "https" "ssh"]`,
+				`system interface/port1/unset alias`,
+			},
+		},
+		{
+			name: "nested blocks",
+			data: "config router bgp\n  set as 65000\n  config neighbor\n    edit \"10.0.0.2\"\n" +
+				"      set remote-as 65001\n    next\n  end\n  config redistribute \"connected\"\n" +
+				"    set status enable\n  end\nend\n",
+			want: []string{
+				`[router bgp]`,
+				`router bgp/as=["65000"]`,
+				`router bgp/[neighbor]`,
+				`router bgp/neighbor/{10.0.0.2}`,
+				`router bgp/neighbor/10.0.0.2/remote-as=["65001"]`,
+				`router bgp/[redistribute connected]`,
+				`router bgp/redistribute connected/status=["enable"]`,
+			},
+		},
+		{
+			name: "end closes edit without next",
+			data: "config firewall address\n  edit a\n    set fqdn example.com\nend\n" +
+				"config firewall addrgrp\nend\n",
+			want: []string{
+				`[firewall address]`,
+				`firewall address/{a}`,
+				`firewall address/a/fqdn=["example.com"]`,
+				`[firewall addrgrp]`,
+			},
+		},
+		{
+			name: "BOM, CRLF and header comments",
+			data: "\ufeff#config-version=FGT60F-7.2.5:opmode=0:vdom=0\r\n#buildno=1517\r\n" +
+				"config system global\r\n    set hostname \"FGT\"\r\nend\r\n",
+			want: []string{
+				`[system global]`,
+				`system global/hostname=["FGT"]`,
+			},
+			comments: []string{"config-version=FGT60F-7.2.5:opmode=0:vdom=0", "buildno=1517"},
+		},
+		{
+			name: "hash in value",
+			data: "config system global\n    set alias a#b\nend\n",
+			want: []string{`[system global]`, `system global/alias=["a#b"]`},
+		},
+		{
+			name: "stray end",
+			data: "config system global\nend\nend\n",
+			err:  "line 3: end without config",
+		},
+		{
+			name: "next without edit",
+			data: "config system global\nnext\nend\n",
+			err:  "line 2: next without edit",
+		},
+		{
+			name: "truncated file",
+			data: "config firewall policy\n  edit 1\n    set name \"x\"\n",
+			err:  `unexpected end of file in "1"`,
+		},
+		{
+			name: "unterminated quoted string",
+			data: "config system global\n    set hostname \"FGT\nend\n",
+			err:  "line 2: unterminated quoted string",
+		},
+		{
+			name: "config without a name",
+			data: "config\nend\n",
+			err:  "line 1: config without a name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, comments, err := parse(tt.data)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("parse() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if got := dump(root, ""); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse() =\n%s\nwant\n%s",
+					strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			if !reflect.DeepEqual(comments, tt.comments) {
+				t.Errorf("comments = %q, want %q", comments, tt.comments)
+			}
+		})
+	}
+}
+
+func TestNodeNil(t *testing.T) {
+	var n *Node
+	if n.Block("x") != nil || n.Entries() != nil || n.Entry("x") != nil ||
+		n.List("x") != nil || n.Get("x") != "" || n.Has("x") ||
+		n.Value("x", "def") != "def" {
+		t.Error("methods of a nil Node must return zero values")
+	}
+}
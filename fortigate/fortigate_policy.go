@@ -0,0 +1,93 @@
+package fortigate
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// utmProfiles is the set commands of security profiles in a policy
+var utmProfiles = []struct{ key, name string }{
+	{"profile-group", "group"},
+	{"av-profile", "av"},
+	{"webfilter-profile", "webfilter"},
+	{"dnsfilter-profile", "dnsfilter"},
+	{"emailfilter-profile", "emailfilter"},
+	{"dlp-profile", "dlp"},
+	{"dlp-sensor", "dlp"},
+	{"file-filter-profile", "file-filter"},
+	{"ips-sensor", "ips"},
+	{"application-list", "app"},
+	{"voip-profile", "voip"},
+	{"waf-profile", "waf"},
+	{"icap-profile", "icap"},
+	{"ssl-ssh-profile", "ssl"},
+}
+
+// profiles returns security profiles of the policy as "type: name".
+func profiles(n *Node) []string {
+	var s []string
+	for _, p := range utmProfiles {
+		if v := n.Get(p.key); v != "" {
+			s = append(s, p.name+": "+v)
+		}
+	}
+	return s
+}
+
+// negate returns the members of <key>. Each member is marked when
+// <key>-negate is enabled.
+func negate(n *Node, key string) []string {
+	if n.Get(key+"-negate") != "enable" {
+		return n.List(key)
+	}
+	var s []string
+	for _, m := range n.List(key) {
+		s = append(s, "(否定) "+m)
+	}
+	return s
+}
+
+// addresses returns the IPv4 and IPv6 members of srcaddr or dstaddr with
+// their own negate flags.
+func addresses(n *Node, key string) []string {
+	return slices.Concat(negate(n, key), negate(n, key+"6"))
+}
+
+// outputPolicy() is <config firewall policy> output process.
+func outputPolicy(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("ポリシー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputPolicy: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"ID", 6}, {"名前", 20}, {"送信元インターフェイス", 16},
+		{"宛先インターフェイス", 16}, {"送信元", 30}, {"宛先", 30},
+		{"VIP", 20}, {"サービス", 20}, {"スケジュール", 10}, {"アクション", 8},
+		{"NAT", 8}, {"IPプール", 8}, {"プール名", 16},
+		{"セキュリティプロファイル", 30}, {"検査モード", 8}, {"ログ", 8},
+		{"状態", 8}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputPolicy: %w", err)
+	}
+	for i, e := range vdom.Block("firewall policy").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("name"), e.List("srcintf"), e.List("dstintf"),
+			addresses(e, "srcaddr"), addresses(e, "dstaddr"),
+			vips(vdom.Node, slices.Concat(e.List("dstaddr"), e.List("dstaddr6"))),
+			negate(e, "service"),
+			e.Get("schedule"), e.Value("action", "deny"),
+			e.Value("nat", "disable"), e.Value("ippool", "disable"),
+			e.List("poolname"), profiles(e), e.Value("inspection-mode", "flow"),
+			e.Value("logtraffic", "utm"), e.Value("status", "enable"),
+			e.Get("comments")})
+		if err != nil {
+			return fmt.Errorf("outputPolicy: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputPolicy: %w", err)
+	}
+	return nil
+}
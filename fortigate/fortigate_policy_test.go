@@ -0,0 +1,38 @@
+package fortigate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegate(t *testing.T) {
+	config, err := newConfig(`config firewall policy
+    edit 1
+        set srcaddr "a4"
+        set srcaddr6 "a6"
+        set srcaddr6-negate enable
+        set dstaddr "b4"
+        set dstaddr-negate enable
+        set dstaddr6 "b6"
+        set service "HTTP" "HTTPS"
+        set service-negate enable
+    next
+end
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := config.Root.Block("firewall policy").Entry("1")
+	tests := []struct {
+		got, want []string
+	}{
+		{addresses(e, "srcaddr"), []string{"a4", "(否定) a6"}},
+		{addresses(e, "dstaddr"), []string{"(否定) b4", "b6"}},
+		{negate(e, "service"), []string{"(否定) HTTP", "(否定) HTTPS"}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
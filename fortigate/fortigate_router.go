@@ -0,0 +1,287 @@
+package fortigate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// routerSetting is a row of BGP and OSPF sheets
+type routerSetting struct {
+	category string
+	name     string
+	value    string
+}
+
+// redistribute returns enabled redistribute blocks of BGP or OSPF.
+func redistribute(router *Node) []routerSetting {
+	if router == nil {
+		return nil
+	}
+	var s []routerSetting
+	for _, c := range router.Config {
+		proto, ok := strings.CutPrefix(c.Name, "redistribute ")
+		if !ok || c.Get("status") != "enable" {
+			continue
+		}
+		s = append(s, routerSetting{"再配布", proto, c.Get("route-map")})
+	}
+	return s
+}
+
+// staticRoute is a row of the static route sheet.
+type staticRoute struct {
+	family, id, dst, gateway, device, distance, priority, blackhole string
+	sdwan, status, comment                                          string
+}
+
+// staticRoutes returns the IPv4 and IPv6 static routes with the FortiOS
+// defaults.
+func staticRoutes(vdom *Node) []staticRoute {
+	var s []staticRoute
+	for _, family := range []struct {
+		name  string
+		block string
+		dst   func(e *Node) string
+	}{
+		{"IPv4", "router static", func(e *Node) string {
+			if e.Has("dstaddr") {
+				return e.Get("dstaddr")
+			}
+			if !e.Has("dst") {
+				return "0.0.0.0/0"
+			}
+			return cidr(e.List("dst"))
+		}},
+		{"IPv6", "router static6", func(e *Node) string {
+			return e.Value("dst", "::/0")
+		}},
+	} {
+		for _, e := range vdom.Block(family.block).Entries() {
+			sdwan := e.Get("sdwan-zone")
+			if sdwan == "" && (e.Get("sdwan") == "enable" || e.Get("virtual-wan-link") == "enable") {
+				sdwan = "enable"
+			}
+			s = append(s, staticRoute{
+				family.name, e.Name, family.dst(e), e.Get("gateway"),
+				e.Get("device"), e.Value("distance", "10"),
+				e.Value("priority", "1"), e.Value("blackhole", "disable"), sdwan,
+				e.Value("status", "enable"), e.Get("comment")})
+		}
+	}
+	return s
+}
+
+// outputRouterStatic() is <config router static> output process.
+func outputRouterStatic(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("スタティックルート")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputRouterStatic: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"種別", 6}, {"ID", 6}, {"宛先", 20}, {"ゲートウェイ", 16},
+		{"インターフェイス", 16}, {"ディスタンス", 6}, {"優先度", 6},
+		{"ブラックホール", 8}, {"SD-WAN", 10}, {"状態", 8}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputRouterStatic: %w", err)
+	}
+	for i, e := range staticRoutes(vdom.Node) {
+		err := xl.SetRow(&[]any{
+			i + 1, e.family, e.id, e.dst, e.gateway, e.device, e.distance,
+			e.priority, e.blackhole, e.sdwan, e.status, e.comment})
+		if err != nil {
+			return fmt.Errorf("outputRouterStatic: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputRouterStatic: %w", err)
+	}
+	return nil
+}
+
+// policyRoute is a row of the policy route sheet.
+type policyRoute struct {
+	id                              string
+	inputDevice, src, dst           []string
+	protocol, port, action, gateway string
+	outputDevice, status, comments  string
+}
+
+// policyRoutes returns the policy routes with the FortiOS defaults.
+func policyRoutes(vdom *Node) []policyRoute {
+	var s []policyRoute
+	for _, e := range vdom.Block("router policy").Entries() {
+		port := ""
+		if e.Has("start-port") || e.Has("end-port") {
+			port = e.Value("start-port", "1") + "-" + e.Value("end-port", "65535")
+		}
+		s = append(s, policyRoute{
+			e.Name, e.List("input-device"),
+			slices.Concat(e.List("srcaddr"), e.List("src")),
+			slices.Concat(e.List("dstaddr"), e.List("dst")),
+			e.Value("protocol", "0"), port, e.Value("action", "permit"),
+			e.Get("gateway"), e.Get("output-device"), e.Value("status", "enable"),
+			e.Get("comments")})
+	}
+	return s
+}
+
+// outputRouterPolicy() is <config router policy> output process.
+func outputRouterPolicy(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("ポリシールート")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputRouterPolicy: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"ID", 6}, {"受信インターフェイス", 16}, {"送信元", 24},
+		{"宛先", 24}, {"プロトコル", 8}, {"ポート", 12}, {"アクション", 8},
+		{"ゲートウェイ", 16}, {"送信インターフェイス", 16}, {"状態", 8},
+		{"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputRouterPolicy: %w", err)
+	}
+	for i, e := range policyRoutes(vdom.Node) {
+		err := xl.SetRow(&[]any{
+			i + 1, e.id, e.inputDevice, e.src, e.dst, e.protocol, e.port,
+			e.action, e.gateway, e.outputDevice, e.status, e.comments})
+		if err != nil {
+			return fmt.Errorf("outputRouterPolicy: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputRouterPolicy: %w", err)
+	}
+	return nil
+}
+
+// writeRouterSettings writes the rows of BGP and OSPF sheets.
+func writeRouterSettings(xl *excel.Excel, sheet string, rows []routerSetting) error {
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("writeRouterSettings: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"カテゴリ", 14}, {"設定", 24}, {"設定値", 30},
+	}); err != nil {
+		return fmt.Errorf("writeRouterSettings: %w", err)
+	}
+	for i, e := range rows {
+		if err := xl.SetRow(&[]any{i + 1, e.category, e.name, e.value}); err != nil {
+			return fmt.Errorf("writeRouterSettings: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("writeRouterSettings: %w", err)
+	}
+	return nil
+}
+
+// bgpSettings returns the rows of the BGP sheet.
+func bgpSettings(bgp *Node) []routerSetting {
+	var rows []routerSetting
+	if bgp != nil {
+		rows = []routerSetting{
+			{"基本", "AS", bgp.Get("as")},
+			{"基本", "ルーターID", bgp.Get("router-id")},
+			{"基本", "keepalive-timer", bgp.Value("keepalive-timer", "60")},
+			{"基本", "holdtime-timer", bgp.Value("holdtime-timer", "180")},
+		}
+	}
+	for _, e := range bgp.Block("network").Entries() {
+		rows = append(rows, routerSetting{"ネットワーク", e.Name, cidr(e.List("prefix"))})
+	}
+	for _, e := range bgp.Block("network6").Entries() {
+		rows = append(rows, routerSetting{"ネットワーク", e.Name, e.Get("prefix6")})
+	}
+	return append(rows, redistribute(bgp)...)
+}
+
+// outputRouterBGP() is <config router bgp> output process.
+func outputRouterBGP(xl *excel.Excel, vdom *VDOM) error {
+	bgp := vdom.Block("router bgp")
+	if err := writeRouterSettings(xl, vdom.sheetName("BGP"), bgpSettings(bgp)); err != nil {
+		return fmt.Errorf("outputRouterBGP: %w", err)
+	}
+
+	sheet := vdom.sheetName("BGPネイバー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputRouterBGP: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"ネイバー", 18}, {"リモートAS", 10}, {"送信元", 12},
+		{"eBGPマルチホップ", 8}, {"ルートマップ(in)", 16},
+		{"ルートマップ(out)", 16}, {"BFD", 8}, {"パスワード", 12},
+		{"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputRouterBGP: %w", err)
+	}
+	for i, e := range bgp.Block("neighbor").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("remote-as"), e.Get("update-source"),
+			e.Value("ebgp-enforce-multihop", "disable"), e.Get("route-map-in"),
+			e.Get("route-map-out"), e.Value("bfd", "disable"), redact(e.Get("password")),
+			e.Get("description")})
+		if err != nil {
+			return fmt.Errorf("outputRouterBGP: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputRouterBGP: %w", err)
+	}
+	return nil
+}
+
+// ospfSettings returns the rows of the OSPF sheet.
+func ospfSettings(ospf *Node) []routerSetting {
+	var rows []routerSetting
+	if ospf != nil {
+		rows = []routerSetting{
+			{"基本", "ルーターID", ospf.Get("router-id")},
+			{"基本", "default-information-originate",
+				ospf.Value("default-information-originate", "disable")},
+		}
+	}
+	for _, e := range ospf.Block("area").Entries() {
+		rows = append(rows, routerSetting{"エリア", e.Name, e.Value("type", "regular")})
+	}
+	for _, e := range ospf.Block("network").Entries() {
+		rows = append(rows, routerSetting{"ネットワーク", e.Name,
+			cidr(e.List("prefix")) + " area " + e.Value("area", "0.0.0.0")})
+	}
+	return append(rows, redistribute(ospf)...)
+}
+
+// outputRouterOSPF() is <config router ospf> output process.
+func outputRouterOSPF(xl *excel.Excel, vdom *VDOM) error {
+	ospf := vdom.Block("router ospf")
+	if err := writeRouterSettings(xl, vdom.sheetName("OSPF"), ospfSettings(ospf)); err != nil {
+		return fmt.Errorf("outputRouterOSPF: %w", err)
+	}
+
+	sheet := vdom.sheetName("OSPFインターフェイス")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputRouterOSPF: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 16}, {"インターフェイス", 16}, {"コスト", 6},
+		{"優先度", 6}, {"Hello間隔", 8}, {"Dead間隔", 8}, {"ネットワーク種別", 14},
+		{"認証", 10}, {"BFD", 8},
+	}); err != nil {
+		return fmt.Errorf("outputRouterOSPF: %w", err)
+	}
+	for i, e := range ospf.Block("ospf-interface").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("interface"), e.Value("cost", "0"),
+			e.Value("priority", "1"), e.Value("hello-interval", "10"),
+			e.Value("dead-interval", "40"), e.Get("network-type"),
+			e.Value("authentication", "none"), e.Get("bfd")})
+		if err != nil {
+			return fmt.Errorf("outputRouterOSPF: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputRouterOSPF: %w", err)
+	}
+	return nil
+}
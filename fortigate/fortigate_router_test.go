@@ -0,0 +1,185 @@
+package fortigate
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteExcelWithoutRouting(t *testing.T) {
+	config, err := newConfig(`config system global
+    set hostname "FGT"
+end
+config firewall policy
+    edit 1
+        set srcintf "port1"
+        set dstintf "port2"
+    next
+end
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, block := range []string{"router static", "router policy", "router bgp", "router ospf"} {
+		if config.Root.Block(block) != nil {
+			t.Fatalf("config has %q", block)
+		}
+	}
+	if got := redistribute(nil); got != nil {
+		t.Errorf("redistribute(nil) = %v, want nil", got)
+	}
+	if err := writeExcel(filepath.Join(t.TempDir(), "out.xlsx"), config); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRouterRows(t *testing.T) {
+	config, err := newConfig(`config router static
+    edit 1
+        set gateway 192.0.2.1
+        set device "port1"
+    next
+    edit 2
+        set dst 10.0.0.0 255.0.0.0
+        set sdwan-zone "virtual-wan-link"
+        set distance 5
+    next
+    edit 3
+        set dstaddr "branch"
+        set virtual-wan-link enable
+        set status disable
+    next
+end
+config router static6
+    edit 1
+        set gateway 2001:db8::1
+        set device "port1"
+    next
+end
+config router policy
+    edit 1
+        set input-device "port2"
+        set src "10.1.0.0/255.255.0.0"
+        set dstaddr "web"
+        set protocol 6
+        set start-port 80
+        set end-port 443
+        set gateway 192.0.2.254
+        set output-device "port3"
+    next
+    edit 2
+        set input-device "port2"
+        set end-port 1024
+    next
+    edit 3
+        set input-device "port2"
+    next
+end
+config router bgp
+    set as 65001
+    set router-id 192.0.2.10
+    config network
+        edit 1
+            set prefix 10.0.0.0 255.0.0.0
+        next
+    end
+    config network6
+        edit 1
+            set prefix6 2001:db8::/32
+        next
+    end
+    config redistribute "connected"
+        set status enable
+        set route-map "rm-connected"
+    end
+    config redistribute "static"
+    end
+    config redistribute "ospf"
+        set status enable
+    end
+end
+config router ospf
+    set router-id 192.0.2.10
+    config area
+        edit 0.0.0.0
+        next
+        edit 0.0.0.1
+            set type stub
+        next
+    end
+    config network
+        edit 1
+            set prefix 10.0.0.0 255.255.255.0
+        next
+        edit 2
+            set prefix 10.1.0.0 255.255.0.0
+            set area 0.0.0.1
+        next
+    end
+    config redistribute "connected"
+        set status enable
+    end
+end
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vdom := config.Root
+
+	wantStatic := []staticRoute{
+		{"IPv4", "1", "0.0.0.0/0", "192.0.2.1", "port1", "10", "1", "disable", "", "enable", ""},
+		{"IPv4", "2", "10.0.0.0/8", "", "", "5", "1", "disable", "virtual-wan-link", "enable", ""},
+		{"IPv4", "3", "branch", "", "", "10", "1", "disable", "enable", "disable", ""},
+		{"IPv6", "1", "::/0", "2001:db8::1", "port1", "10", "1", "disable", "", "enable", ""},
+	}
+	if got := staticRoutes(vdom); !reflect.DeepEqual(got, wantStatic) {
+		t.Errorf("staticRoutes() =\n%q\nwant\n%q", got, wantStatic)
+	}
+
+	var ports []string
+	for _, e := range policyRoutes(vdom) {
+		ports = append(ports, e.port)
+	}
+	if want := []string{"80-443", "1-1024", ""}; !reflect.DeepEqual(ports, want) {
+		t.Errorf("policy route ports = %q, want %q", ports, want)
+	}
+	policy := policyRoutes(vdom)[0]
+	if want := []string{"10.1.0.0/255.255.0.0"}; !reflect.DeepEqual(policy.src, want) {
+		t.Errorf("policy route src = %q, want %q", policy.src, want)
+	}
+	if want := []string{"web"}; !reflect.DeepEqual(policy.dst, want) {
+		t.Errorf("policy route dst = %q, want %q", policy.dst, want)
+	}
+
+	tests := []struct {
+		name string
+		got  []routerSetting
+		want []routerSetting
+	}{
+		{"BGP", bgpSettings(vdom.Block("router bgp")), []routerSetting{
+			{"基本", "AS", "65001"},
+			{"基本", "ルーターID", "192.0.2.10"},
+			{"基本", "keepalive-timer", "60"},
+			{"基本", "holdtime-timer", "180"},
+			{"ネットワーク", "1", "10.0.0.0/8"},
+			{"ネットワーク", "1", "2001:db8::/32"},
+			{"再配布", "connected", "rm-connected"},
+			{"再配布", "ospf", ""},
+		}},
+		{"OSPF", ospfSettings(vdom.Block("router ospf")), []routerSetting{
+			{"基本", "ルーターID", "192.0.2.10"},
+			{"基本", "default-information-originate", "disable"},
+			{"エリア", "0.0.0.0", "regular"},
+			{"エリア", "0.0.0.1", "stub"},
+			{"ネットワーク", "1", "10.0.0.0/24 area 0.0.0.0"},
+			{"ネットワーク", "2", "10.1.0.0/16 area 0.0.0.1"},
+			{"再配布", "connected", ""},
+		}},
+		{"no routing", bgpSettings(nil), nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s =\n%q\nwant\n%q", tt.name, tt.got, tt.want)
+		}
+	}
+}
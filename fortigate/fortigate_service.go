@@ -0,0 +1,125 @@
+package fortigate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// portRanges formats "dst[-dst][:src[-src]]" port ranges.
+func portRanges(v []string) []string {
+	s := make([]string, 0, len(v))
+	for _, r := range v {
+		if dst, src, ok := strings.Cut(r, ":"); ok {
+			s = append(s, fmt.Sprintf("%s (送信元 %s)", dst, src))
+		} else {
+			s = append(s, r)
+		}
+	}
+	return s
+}
+
+// icmp returns ICMP type and code of the service.
+func icmp(e *Node) string {
+	if !e.Has("icmptype") {
+		return ""
+	}
+	s := "type " + e.Get("icmptype")
+	if e.Has("icmpcode") {
+		s += " code " + e.Get("icmpcode")
+	}
+	return s
+}
+
+// outputServiceCustom() is <config firewall service custom> output process.
+func outputServiceCustom(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("サービス")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputServiceCustom: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"カテゴリ", 16}, {"プロトコル", 12},
+		{"TCPポート", 20}, {"UDPポート", 20}, {"SCTPポート", 12},
+		{"ICMP", 14}, {"プロトコル番号", 8}, {"所属グループ", 20},
+		{"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputServiceCustom: %w", err)
+	}
+	groups := vdom.Block("firewall service group")
+	for i, e := range vdom.Block("firewall service custom").Entries() {
+		protocolNumber := ""
+		if e.Get("protocol") == "IP" {
+			protocolNumber = e.Value("protocol-number", "0")
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("category"), e.Value("protocol", "TCP/UDP/SCTP"),
+			portRanges(e.List("tcp-portrange")), portRanges(e.List("udp-portrange")),
+			portRanges(e.List("sctp-portrange")), icmp(e), protocolNumber,
+			memberOf(groups, e.Name), e.Get("comment")})
+		if err != nil {
+			return fmt.Errorf("outputServiceCustom: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputServiceCustom: %w", err)
+	}
+	return nil
+}
+
+// outputServiceGroup() is <config firewall service group> output process.
+func outputServiceGroup(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("サービスグループ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputServiceGroup: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"メンバー", 60}, {"所属グループ", 20},
+		{"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputServiceGroup: %w", err)
+	}
+	groups := vdom.Block("firewall service group")
+	for i, e := range groups.Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.List("member"), memberOf(groups, e.Name),
+			e.Get("comment")})
+		if err != nil {
+			return fmt.Errorf("outputServiceGroup: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputServiceGroup: %w", err)
+	}
+	return nil
+}
+
+// outputServiceCategory() is <config firewall service category> output process.
+func outputServiceCategory(xl *excel.Excel, vdom *VDOM) error {
+	sheet := vdom.sheetName("サービスカテゴリ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputServiceCategory: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"サービス", 60}, {"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputServiceCategory: %w", err)
+	}
+	services := vdom.Block("firewall service custom")
+	for i, e := range vdom.Block("firewall service category").Entries() {
+		var member []string
+		for _, s := range services.Entries() {
+			if s.Get("category") == e.Name {
+				member = append(member, s.Name)
+			}
+		}
+		err := xl.SetRow(&[]any{i + 1, e.Name, member, e.Get("comment")})
+		if err != nil {
+			return fmt.Errorf("outputServiceCategory: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputServiceCategory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package fortigate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// systemSetting is a row of the system setting sheet
+type systemSetting struct {
+	category, name, value, defaultValue string
+}
+
+// systemSettings returns the system settings with the FortiOS defaults.
+func systemSettings(global *Node) []systemSetting {
+	var s []systemSetting
+	for _, b := range []struct {
+		category string
+		block    string
+		keys     [][2]string // key, default
+	}{
+		{"システム", "system global", [][2]string{
+			{"hostname", ""}, {"alias", ""}, {"timezone", ""},
+			{"admin-port", "80"}, {"admin-sport", "443"},
+			{"admin-ssh-port", "22"}, {"admin-telnet-port", "23"},
+			{"admin-https-redirect", "enable"}, {"admintimeout", "5"},
+			{"admin-lockout-threshold", "3"}, {"admin-lockout-duration", "60"},
+			{"strong-crypto", "enable"}, {"ssl-min-proto-version", "TLSv1-2"},
+			{"admin-maintainer", "enable"}, {"gui-certificates", ""},
+		}},
+		{"DNS", "system dns", [][2]string{
+			{"primary", "96.45.45.45"}, {"secondary", "96.45.46.46"},
+			{"protocol", "cleartext"}, {"domain", ""}, {"source-ip", ""},
+		}},
+		{"NTP", "system ntp", [][2]string{
+			{"ntpsync", "enable"}, {"type", "fortiguard"},
+			{"syncinterval", "60"}, {"server-mode", "disable"},
+			{"source-ip", ""},
+		}},
+		{"Syslog", "log syslogd setting", [][2]string{
+			{"status", "disable"}, {"server", ""}, {"mode", "udp"},
+			{"port", "514"}, {"facility", "local7"}, {"source-ip", ""},
+			{"format", "default"},
+		}},
+		{"SNMP", "system snmp sysinfo", [][2]string{
+			{"status", "disable"}, {"description", ""},
+			{"contact-info", ""}, {"location", ""},
+		}},
+	} {
+		n := global.Block(b.block)
+		for _, k := range b.keys {
+			s = append(s, systemSetting{b.category, k[0], n.Get(k[0]), k[1]})
+		}
+		if b.block == "system ntp" {
+			for _, e := range n.Block("ntpserver").Entries() {
+				s = append(s, systemSetting{b.category, "ntpserver " + e.Name, e.Get("server"), ""})
+			}
+		}
+	}
+	return s
+}
+
+// outputSystemSetting() is <config system global, dns, ntp, ...> output process.
+func outputSystemSetting(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("システム設定")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSystemSetting: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"カテゴリ", 10}, {"設定", 30}, {"設定値", 20},
+		{"デフォルト", 12}, {"デフォルト以外", 8},
+	}); err != nil {
+		return fmt.Errorf("outputSystemSetting: %w", err)
+	}
+	for i, e := range systemSettings(config.Global) {
+		changed := ""
+		if e.value != "" && e.value != e.defaultValue {
+			changed = "変更"
+		}
+		err := xl.SetRow(&[]any{i + 1, e.category, e.name, e.value,
+			e.defaultValue, changed})
+		if err != nil {
+			return fmt.Errorf("outputSystemSetting: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSystemSetting: %w", err)
+	}
+	return nil
+}
+
+// trusthosts returns trusthost1-10 and ip6-trusthost1-10 except any.
+func trusthosts(e *Node) []string {
+	var s []string
+	for i := 1; i <= 10; i++ {
+		n := strconv.Itoa(i)
+		if v := cidr(e.List("trusthost" + n)); v != "" && v != "0.0.0.0/0" {
+			s = append(s, v)
+		}
+		if v := e.Get("ip6-trusthost" + n); v != "" && v != "::/0" {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+// outputAdmin() is <config system admin> output process.
+func outputAdmin(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("管理者")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputAdmin: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 16}, {"アクセスプロファイル", 16}, {"VDOM", 12},
+		{"信頼ホスト", 24}, {"2要素認証", 10}, {"パスワード", 12},
+		{"コメント", 60},
+	}); err != nil {
+		return fmt.Errorf("outputAdmin: %w", err)
+	}
+	for i, e := range config.Global.Block("system admin").Entries() {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Get("accprofile"), e.List("vdom"), trusthosts(e),
+			e.Value("two-factor", "disable"), redact(e.Get("password")),
+			e.Get("comments")})
+		if err != nil {
+			return fmt.Errorf("outputAdmin: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputAdmin: %w", err)
+	}
+	return nil
+}
+
+// accprofileGroups is the permission groups of an access profile
+var accprofileGroups = []string{
+	"secfabgrp", "ftviewgrp", "authgrp", "sysgrp", "netgrp", "loggrp",
+	"fwgrp", "vpngrp", "utmgrp", "wanoptgrp", "wifi",
+}
+
+// outputAccprofile() is <config system accprofile> output process.
+func outputAccprofile(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("アクセスプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputAccprofile: %w", err)
+	}
+	header := []excel.Header{{"#", 4}, {"名前", 16}, {"スコープ", 8}}
+	for _, g := range accprofileGroups {
+		header = append(header, excel.Header{g, 10})
+	}
+	header = append(header, excel.Header{"コメント", 60})
+	if err := xl.SetHeader(header); err != nil {
+		return fmt.Errorf("outputAccprofile: %w", err)
+	}
+	for i, e := range config.Global.Block("system accprofile").Entries() {
+		row := []any{i + 1, e.Name, e.Value("scope", "vdom")}
+		for _, g := range accprofileGroups {
+			row = append(row, e.Value(g, "none"))
+		}
+		row = append(row, e.Get("comments"))
+		if err := xl.SetRow(&row); err != nil {
+			return fmt.Errorf("outputAccprofile: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputAccprofile: %w", err)
+	}
+	return nil
+}
+
+// outputSNMP() is <config system snmp community, user> output process.
+func outputSNMP(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("SNMP")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSNMP: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"バージョン", 8}, {"タイプ", 12}, {"名前", 20},
+		{"ホスト", 30}, {"セキュリティレベル", 16}, {"認証", 16},
+		{"暗号化", 16}, {"状態", 8},
+	}); err != nil {
+		return fmt.Errorf("outputSNMP: %w", err)
+	}
+	r := 0
+	for _, e := range config.Global.Block("system snmp community").Entries() {
+		var hosts []string
+		for _, h := range e.Block("hosts").Entries() {
+			hosts = append(hosts, strings.TrimSpace(cidr(h.List("ip"))+" "+h.Get("interface")))
+		}
+		var versions []string
+		for _, v := range []string{"v1", "v2c"} {
+			if e.Value("query-"+v+"-status", "enable") == "enable" ||
+				e.Value("trap-"+v+"-status", "enable") == "enable" {
+				versions = append(versions, v)
+			}
+		}
+		r++
+		err := xl.SetRow(&[]any{r, strings.Join(versions, "/"), "コミュニティ",
+			redact(e.Get("name")), hosts, "", "", "", e.Value("status", "enable")})
+		if err != nil {
+			return fmt.Errorf("outputSNMP: %w", err)
+		}
+	}
+	for _, e := range config.Global.Block("system snmp user").Entries() {
+		level := e.Value("security-level", "no-auth-no-priv")
+		auth, priv := "", ""
+		if level != "no-auth-no-priv" {
+			auth = e.Value("auth-proto", "sha") + " " + redact(e.Get("auth-pwd"))
+		}
+		if level == "auth-priv" {
+			priv = e.Value("priv-proto", "aes") + " " + redact(e.Get("priv-pwd"))
+		}
+		r++
+		err := xl.SetRow(&[]any{r, "v3", "ユーザー", e.Name,
+			e.List("notify-hosts"), level, auth, priv, e.Value("status", "enable")})
+		if err != nil {
+			return fmt.Errorf("outputSNMP: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSNMP: %w", err)
+	}
+	return nil
+}
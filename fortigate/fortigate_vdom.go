@@ -0,0 +1,143 @@
+package fortigate
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/nonsugar-go/tomato-conv/internal/sheetname"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// VDOM is config vdom>edit, or the root of a config without VDOMs
+type VDOM struct {
+	*Node
+	prefix string         // prefix of sheet names
+	sheets *sheetname.Set // sheet names used in the workbook
+}
+
+// sheetName returns a sheet name with the prefix of the VDOM, which is
+// unique in the workbook.
+func (v *VDOM) sheetName(name string) string {
+	return v.sheets.Name(v.prefix, name)
+}
+
+// splitVDOM splits the config into config global and config vdom>edit.
+// Multi-VDOM configs declare VDOMs in the first config vdom block and
+// define their settings in the later config vdom blocks.
+func (c *Config) splitVDOM() {
+	c.Global = c.Root.Block("global")
+	if c.Global == nil {
+		c.Global = c.Root
+		c.VDOM = []*VDOM{{Node: c.Root}}
+		return
+	}
+	for _, b := range c.Root.Config {
+		if b.Name != "vdom" {
+			continue
+		}
+		for _, e := range b.Edit {
+			if v := c.vdom(e.Name); v != nil {
+				v.Config = append(v.Config, e.Config...)
+				continue
+			}
+			c.VDOM = append(c.VDOM, &VDOM{Node: e, prefix: e.Name + " "})
+		}
+	}
+}
+
+// vdom returns the VDOM with the name. Without VDOMs, it returns the root.
+func (c *Config) vdom(name string) *VDOM {
+	if c.Global == c.Root && len(c.VDOM) > 0 {
+		return c.VDOM[0]
+	}
+	for _, v := range c.VDOM {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// zoneOfVDOM returns the zone of the interface in the VDOM.
+func zoneOfVDOM(v *VDOM, intf string) string {
+	if v == nil {
+		return ""
+	}
+	return zoneOf(v.Node, intf)
+}
+
+// outputVDOM() is <config vdom> output process.
+func outputVDOM(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("VDOM")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputVDOM: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 16}, {"運用モード", 10}, {"インターフェイス", 60},
+		{"ポリシー数", 8},
+	}); err != nil {
+		return fmt.Errorf("outputVDOM: %w", err)
+	}
+	for i, v := range config.VDOM {
+		var intf []string
+		for _, e := range config.Global.Block("system interface").Entries() {
+			if e.Get("vdom") == v.Name {
+				intf = append(intf, e.Name)
+			}
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, v.Name, v.Block("system settings").Value("opmode", "nat"),
+			intf, len(v.Block("firewall policy").Entries())})
+		if err != nil {
+			return fmt.Errorf("outputVDOM: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputVDOM: %w", err)
+	}
+	return nil
+}
+
+// npuVlink matches the interfaces of NP inter-VDOM links (npu0_vlink0, ...)
+var npuVlink = regexp.MustCompile(`^(npu\d+_vlink)[01]$`)
+
+// outputVDOMLink() is <config system vdom-link> output process.
+func outputVDOMLink(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("VDOMリンク")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputVDOMLink: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 16}, {"タイプ", 8}, {"インターフェイス0", 16},
+		{"VDOM0", 12}, {"IPアドレス0", 18}, {"インターフェイス1", 16},
+		{"VDOM1", 12}, {"IPアドレス1", 18},
+	}); err != nil {
+		return fmt.Errorf("outputVDOMLink: %w", err)
+	}
+	intfs := config.Global.Block("system interface")
+	type link struct{ name, typ string }
+	var links []link
+	for _, e := range config.Global.Block("system vdom-link").Entries() {
+		links = append(links, link{e.Name, e.Value("type", "ppp")})
+	}
+	for _, e := range intfs.Entries() {
+		if m := npuVlink.FindStringSubmatch(e.Name); m != nil && e.Name[len(e.Name)-1] == '0' {
+			links = append(links, link{m[1], "npu"})
+		}
+	}
+	for i, l := range links {
+		row := []any{i + 1, l.name, l.typ}
+		for _, end := range []string{"0", "1"} {
+			e := intfs.Entry(l.name + end)
+			row = append(row, l.name+end, e.Get("vdom"), cidr(e.List("ip")))
+		}
+		if err := xl.SetRow(&row); err != nil {
+			return fmt.Errorf("outputVDOMLink: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputVDOMLink: %w", err)
+	}
+	return nil
+}
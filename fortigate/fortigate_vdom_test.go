@@ -0,0 +1,54 @@
+package fortigate
+
+import (
+	"testing"
+
+	"github.com/nonsugar-go/tomato-conv/internal/sheetname"
+)
+
+func TestVDOMSheetName(t *testing.T) {
+	config, err := newConfig(`config vdom
+edit "Branch-Office-VDOM-Tokyo-01"
+next
+edit "Branch-Office-VDOM-Tokyo-02"
+next
+end
+config global
+end
+config vdom
+edit "Branch-Office-VDOM-Tokyo-01"
+config firewall policy
+    edit 1
+    next
+end
+next
+edit "Branch-Office-VDOM-Tokyo-02"
+config firewall policy
+    edit 1
+    next
+end
+next
+end
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.VDOM) != 2 {
+		t.Fatalf("len(VDOM) = %d, want 2", len(config.VDOM))
+	}
+	config.sheets = &sheetname.Set{}
+	used := map[string]string{}
+	for _, v := range config.VDOM {
+		v.sheets = config.sheets
+		if n := len(v.Block("firewall policy").Entries()); n != 1 {
+			t.Errorf("%s: %d policies, want 1", v.Name, n)
+		}
+		for _, name := range []string{"ポリシー", "OSPFインターフェイス"} {
+			sheet := v.sheetName(name)
+			if other, ok := used[sheet]; ok {
+				t.Errorf("%s and %s share the sheet %q", other, v.Name, sheet)
+			}
+			used[sheet] = v.Name
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package sheetname builds Excel sheet names that are unique in a workbook.
+package sheetname
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLen is the number of characters allowed in an Excel sheet name
+const maxLen = 31
+
+// Set is the set of sheet names used in a workbook
+type Set struct {
+	used map[string]bool
+}
+
+// Name returns the sheet name with the prefix. The prefix is shortened so
+// that the sheet name fits in the 31 characters allowed by Excel, and "~2",
+// "~3", ... is appended when the name is already used in the workbook
+// because Excel would otherwise reuse the existing sheet.
+func (s *Set) Name(prefix, name string) string {
+	if s == nil {
+		return join(prefix, name, "")
+	}
+	if s.used == nil {
+		s.used = map[string]bool{}
+	}
+	for i := 1; ; i++ {
+		suffix := ""
+		if i > 1 {
+			suffix = "~" + strconv.Itoa(i)
+		}
+		n := join(prefix, name, suffix)
+		// Excel compares sheet names case-insensitively
+		if key := strings.ToLower(n); !s.used[key] {
+			s.used[key] = true
+			return n
+		}
+	}
+}
+
+// Has reports whether the sheet name is used in the workbook.
+func (s *Set) Has(name string) bool {
+	return s != nil && s.used[strings.ToLower(name)]
+}
+
+// join joins the prefix, the name and the suffix within maxLen characters.
+// The suffix is never cut off. When the prefix is too long, its last word,
+// such as the "pre"/"post" rulebase or the vsys name, is kept and the words
+// before it, such as the device group name, are shortened.
+func join(prefix, name, suffix string) string {
+	p := []rune(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, prefix))
+	name += suffix
+	if r := []rune(name); len(r) >= maxLen {
+		s := []rune(suffix)
+		return string(r[:maxLen-len(s)]) + suffix
+	}
+	n := maxLen - utf8.RuneCountInString(name)
+	if len(p) <= n {
+		return string(p) + name
+	}
+	// shorten head and keep tail, e.g. "DG-name" and " pre " of "DG-name pre "
+	head, tail := p, []rune(" ")
+	if i := strings.LastIndex(strings.TrimRight(string(p), " "), " "); i > 0 {
+		head = []rune(string(p)[:i])
+		tail = []rune(string(p)[i:])
+	}
+	switch m := n - len(tail); {
+	case m > 0:
+		p = append(head[:m:m], tail...)
+	case len(tail)-1 <= n && len(tail) > 1:
+		p = tail[1:]
+	default:
+		p = nil
+	}
+	return string(p) + name
+}
@@ -0,0 +1,68 @@
+package sheetname
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestName(t *testing.T) {
+	var s Set
+	tests := []struct {
+		prefix, name, want string
+	}{
+		{"", "セキュリティ", "セキュリティ"},
+		{"shared ", "アドレス", "shared アドレス"},
+		{"a:b/c ", "NAT", "a_b_c NAT"},
+		// pre and post rulebases of a long device group name
+		// keep the rulebase and shorten the device group name
+		{"Branch-Office-Tokyo-DG pre ", "セキュリティ", "Branch-Office-Tokyo- pre セキュリティ"},
+		{"Branch-Office-Tokyo-DG post ", "セキュリティ", "Branch-Office-Tokyo post セキュリティ"},
+		{"Branch-Office-Tokyo-DG pre ", "セキュリティ", "Branch-Office-Toky pre セキュリティ~2"},
+		// only the rulebase is left when the name is long
+		{"DG pre ", "012345678901234567890123456", "pre 012345678901234567890123456"},
+		// Excel compares sheet names case-insensitively
+		{"SHARED ", "アドレス", "SHARED アドレス~2"},
+		// names which use up the 31 characters keep the suffix
+		{"prefix", "0123456789012345678901234567890123", "0123456789012345678901234567890"},
+		{"other", "0123456789012345678901234567890123", "01234567890123456789012345678~2"},
+		{"", "0123456789012345678901234567890", "01234567890123456789012345678~3"},
+	}
+	for _, tt := range tests {
+		got := s.Name(tt.prefix, tt.name)
+		if got != tt.want {
+			t.Errorf("Name(%q, %q) = %q, want %q", tt.prefix, tt.name, got, tt.want)
+		}
+		if n := utf8.RuneCountInString(got); n > maxLen {
+			t.Errorf("Name(%q, %q) = %q has %d characters", tt.prefix, tt.name, got, n)
+		}
+	}
+}
+
+func TestNameNil(t *testing.T) {
+	var s *Set
+	if got, want := s.Name("vsys1 ", "ゾーン"), "vsys1 ゾーン"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+}
+
+func TestHas(t *testing.T) {
+	var s Set
+	name := s.Name("tmpl ", "SNMP")
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{name, true},
+		{"TMPL snmp", true},
+		{"tmpl SNMP~2", false},
+		{"SNMP", false},
+	} {
+		if got := s.Has(tt.name); got != tt.want {
+			t.Errorf("Has(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	var nilSet *Set
+	if nilSet.Has(name) {
+		t.Errorf("nil Set has %q", name)
+	}
+}
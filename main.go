@@ -9,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/log"
 
+	"github.com/nonsugar-go/tomato-conv/fortigate"
 	"github.com/nonsugar-go/tomato-conv/paloalto"
 	"github.com/nonsugar-go/tools/tui"
 )
@@ -19,6 +20,12 @@ func main() {
 		outType: OutTypeExcel,
 	}
 	var devTypeStr string
+	paOpts := paloalto.Options{
+		CertWarningDays: paloalto.DefaultCertWarningDays,
+		SelectArchiveEntry: func(names []string) (string, error) {
+			return tui.Select("変換する設定ファイルを選択してください", names)
+		},
+	}
 	flag.StringVar(
 		&devTypeStr, "dev", "", "機器の種類 {fgt|pa}",
 	)
@@ -28,6 +35,14 @@ func main() {
 	flag.StringVar(
 		&confInfo.outFilename, "out", "", "出力ファイル",
 	)
+	flag.IntVar(
+		&paOpts.CertWarningDays, "certdays", paOpts.CertWarningDays,
+		"証明書の有効期限を警告する日数",
+	)
+	flag.StringVar(
+		&paOpts.ArchiveEntry, "entry", "",
+		"アーカイブ内の設定ファイル (例: running-config.xml)",
+	)
 	flag.Parse()
 	switch {
 	case strings.EqualFold(devTypeStr, "fortigate") ||
@@ -48,7 +63,7 @@ func main() {
 	if confInfo.confFilename == "" {
 		ext := map[DevType][]string{
 			DevTypeFortiGate: {".conf"},
-			DevTypePaloAlto:  {".xml", ".tgz"},
+			DevTypePaloAlto:  {".xml", ".tgz", ".gz", ".zip"},
 		}
 		var err error
 		for {
@@ -75,9 +90,14 @@ func main() {
 	tui.PressAnyKey()
 
 	switch dtype := confInfo.devType; {
+	case dtype == DevTypeFortiGate:
+		if err := fortigate.ConvertFGTConfig(
+			confInfo.confFilename, confInfo.outFilename); err != nil {
+			log.Errorf("FortiGate の設定表作成が失敗しました: %v", err)
+		}
 	case dtype == DevTypePaloAlto:
 		if err := paloalto.ConvertPAConfig(
-			confInfo.confFilename, confInfo.outFilename); err != nil {
+			confInfo.confFilename, confInfo.outFilename, paOpts); err != nil {
 			log.Errorf("PaloAlto の設定表作成が失敗しました: %v", err)
 		}
 	default:
@@ -1,17 +1,15 @@
 package paloalto
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"encoding/xml"
 	"fmt"
-	"io"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/nonsugar-go/tomato-conv/internal/sheetname"
 	"github.com/nonsugar-go/tools/excel"
 )
 
@@ -140,13 +138,31 @@ import (
 
 // Config is root element
 type Config struct {
-	XMLName       xml.Name        `xml:"config"`
-	Version       string          `xml:"version,attr"`
-	DetailVersion string          `xml:"detail-version,attr"`
-	Users         []Users         `xml:"mgt-config>users>entry"`
-	Ethernet      []Ethernet      `xml:"devices>entry>network>interface>ethernet>entry"`
-	VirtualRouter []VirtualRouter `xml:"devices>entry>network>virtual-router>entry"`
-	Vsys          []Vsys          `xml:"devices>entry>vsys>entry"`
+	XMLName               xml.Name                `xml:"config"`
+	Version               string                  `xml:"version,attr"`
+	DetailVersion         string                  `xml:"detail-version,attr"`
+	Users                 []Users                 `xml:"mgt-config>users>entry"`
+	PasswordComplexity    PasswordComplexity      `xml:"mgt-config>password-complexity"`
+	Ethernet              []Ethernet              `xml:"devices>entry>network>interface>ethernet>entry"`
+	AggregateEthernet     []AggregateEthernet     `xml:"devices>entry>network>interface>aggregate-ethernet>entry"`
+	LLDPProfile           []LLDPProfile           `xml:"devices>entry>network>profiles>lldp-profile>entry"`
+	VirtualWire           []VirtualWire           `xml:"devices>entry>network>virtual-wire>entry"`
+	VLAN                  []VLAN                  `xml:"devices>entry>network>vlan>entry"`
+	SDWANInterfaceProfile []SDWANInterfaceProfile `xml:"devices>entry>network>profiles>sdwan-interface-profile>entry"`
+	VirtualRouter         []VirtualRouter         `xml:"devices>entry>network>virtual-router>entry"`
+	QosProfile            []QosProfile            `xml:"devices>entry>network>qos>profile>entry"`
+	QosInterface          []QosInterface          `xml:"devices>entry>network>qos>interface>entry"`
+	DeviceConfigSystem    DeviceConfigSystem      `xml:"devices>entry>deviceconfig>system"`
+	DeviceConfigSetting   DeviceConfigSetting     `xml:"devices>entry>deviceconfig>setting"`
+	Shared                DeviceGroup             `xml:"shared"`
+	Vsys                  []Vsys                  `xml:"devices>entry>vsys>entry"`
+	DeviceGroup           []DeviceGroup           `xml:"devices>entry>device-group>entry"`
+	DeviceGroupParent     []DeviceGroupParent     `xml:"readonly>devices>entry>device-group>entry"`
+	Template              []Template              `xml:"devices>entry>template>entry"`
+	TemplateStack         []TemplateStack         `xml:"devices>entry>template-stack>entry"`
+	prefix                string                  // prefix of sheet names
+	sheets                *sheetname.Set          // sheet names used in the workbook
+	certWarningDays       int                     // days before expiry to warn
 }
 
 // Users is mgt-config>users>entry
@@ -174,9 +190,11 @@ type Ethernet struct {
 	PortPriority               string        `xml:"lacp>port-priority"`
 	LinkState                  string        `xml:"link-state"`
 	IP                         []EthernetIP  `xml:"layer3>ip>entry"`
+	IPv6                       *EthernetIPv6 `xml:"layer3>ipv6"`
 	InterfaceManagementProfile string        `xml:"layer3>interface-management-profile"`
 	NetflowProfile             string        `xml:"layer3>netflow-profile"`
 	LLDPEnable                 string        `xml:"layer3>lldp>enable"`
+	LLDPProfile                string        `xml:"layer3>lldp>profile"`
 	HA                         *EthernetHA   `xml:"ha"`
 	Comment                    string        `xml:"comment"`
 	EthernetUnits              EthernetUnits `xml:"units>entry"`
@@ -214,16 +232,19 @@ type EthernetUnits struct {
 
 // VirtualRouter is devices>entry>network>virtual-router>entry
 type VirtualRouter struct {
-	Name        string        `xml:"name,attr"`
-	Interface   []string      `xml:"interface>member"`
-	StaticRoute []StaticRoute `xml:"routing-table>ip>static-route>entry"`
+	Name            string        `xml:"name,attr"`
+	Interface       []string      `xml:"interface>member"`
+	StaticRoute     []StaticRoute `xml:"routing-table>ip>static-route>entry"`
+	StaticRouteIPv6 []StaticRoute `xml:"routing-table>ipv6>static-route>entry"`
 }
 
 // StaticRoute is routing-table>ip>static-route>entry
+// and routing-table>ipv6>static-route>entry
 type StaticRoute struct {
 	// TODO: datail modify
 	Name        string `xml:"name,attr"`
 	Nexthop     string `xml:"nexthop>ip-address"`
+	NexthopIPv6 string `xml:"nexthop>ipv6-address"`
 	Bfd         string `xml:"bfd>profile"`
 	Interface   string `xml:"interface"`
 	Metric      string `xml:"metric"`
@@ -232,21 +253,55 @@ type StaticRoute struct {
 
 // Vsys is devices>entry>vsys>entry
 type Vsys struct {
-	Name             string             `xml:"name,attr"`
-	Zone             []Zone             `xml:"zone>entry"`
-	Tag              []Tag              `xml:"tag>entry"`
-	Address          []Address          `xml:"address>entry"`
-	AddressGroup     []AddressGroup     `xml:"address-group>entry"`
-	ApplicationGroup []ApplicationGroup `xml:"application-group>entry"`
-	Service          []Service          `xml:"service>entry"`
-	ServiceGroup     []ServiceGroup     `xml:"service-group>entry"`
-	Security         []Security         `xml:"rulebase>security>rules>entry"`
+	Name                     string                     `xml:"name,attr"`
+	Zone                     []Zone                     `xml:"zone>entry"`
+	Tag                      []Tag                      `xml:"tag>entry"`
+	Address                  []Address                  `xml:"address>entry"`
+	AddressGroup             []AddressGroup             `xml:"address-group>entry"`
+	ExternalList             []ExternalList             `xml:"external-list>entry"`
+	Schedule                 []Schedule                 `xml:"schedule>entry"`
+	Region                   []Region                   `xml:"region>entry"`
+	CustomURLCategory        []CustomURLCategory        `xml:"profiles>custom-url-category>entry"`
+	SDWANPathQuality         []SDWANPathQuality         `xml:"profiles>sdwan-path-quality>entry"`
+	SDWANTrafficDistribution []SDWANTrafficDistribution `xml:"profiles>sdwan-traffic-distribution>entry"`
+	Certificate              []Certificate              `xml:"certificate>entry"`
+	CertificateProfile       []CertificateProfile       `xml:"certificate-profile>entry"`
+	SSLTLSServiceProfile     []SSLTLSServiceProfile     `xml:"ssl-tls-service-profile>entry"`
+	UserIDAgent              []UserIDAgent              `xml:"user-id-agent>entry"`
+	UserIDServerMonitor      []UserIDServerMonitor      `xml:"user-id-collector>server-monitor>entry"`
+	GroupMapping             []GroupMapping             `xml:"group-mapping>entry"`
+	LocalUser                []LocalUser                `xml:"local-user-database>user>entry"`
+	LocalUserGroup           []LocalUserGroup           `xml:"local-user-database>user-group>entry"`
+	DynamicUserGroup         []DynamicUserGroup         `xml:"dynamic-user-group>entry"`
+	HIPObject                []HIPObject                `xml:"hip-objects>entry"`
+	HIPProfile               []HIPProfile               `xml:"hip-profiles>entry"`
+	SyslogProfile            []SyslogProfile            `xml:"log-settings>syslog>entry"`
+	SNMPTrapProfile          []SNMPTrapProfile          `xml:"log-settings>snmptrap>entry"`
+	NetflowProfile           []NetflowProfile           `xml:"server-profile>netflow>entry"`
+	CaptivePortal            *CaptivePortal             `xml:"captive-portal"`
+	AuthenticationPortal     *CaptivePortal             `xml:"authentication-portal"`
+	Application              []Application              `xml:"application>entry"`
+	ApplicationGroup         []ApplicationGroup         `xml:"application-group>entry"`
+	ApplicationFilter        []ApplicationFilter        `xml:"application-filter>entry"`
+	Service                  []Service                  `xml:"service>entry"`
+	ServiceGroup             []ServiceGroup             `xml:"service-group>entry"`
+	Security                 []Security                 `xml:"rulebase>security>rules>entry"`
+	QoS                      []QoS                      `xml:"rulebase>qos>rules>entry"`
+	ApplicationOverride      []ApplicationOverride      `xml:"rulebase>application-override>rules>entry"`
+	Authentication           []Authentication           `xml:"rulebase>authentication>rules>entry"`
+	Nat                      []Nat                      `xml:"rulebase>nat>rules>entry"`
+	SDWAN                    []SDWAN                    `xml:"rulebase>sdwan>rules>entry"`
+	prefix                   string                     // prefix of sheet names
+	sheets                   *sheetname.Set             // sheet names used in the workbook
 }
 
 // Zone is tag>entry
 type Zone struct {
 	Name        string   `xml:"name,attr"`
 	Layer3      []string `xml:"network>layer3>member"`
+	Layer2      []string `xml:"network>layer2>member"`
+	VirtualWire []string `xml:"network>virtual-wire>member"`
+	Tap         []string `xml:"network>tap>member"`
 	Description string   `xml:"description"` // TODO: Check
 }
 
@@ -261,6 +316,7 @@ type Tag struct {
 type Address struct {
 	Name        string   `xml:"name,attr"`
 	IPNetmask   string   `xml:"ip-netmask"`
+	IPRange     string   `xml:"ip-range"`
 	FQDN        string   `xml:"fqdn"`
 	Tag         []string `xml:"tag>member"`
 	Description string   `xml:"description"`
@@ -275,11 +331,11 @@ type AddressGroup struct {
 }
 
 // ApplicationGroup is application-group>entry
+// An application group has no description in PAN-OS.
 type ApplicationGroup struct {
-	Name        string   `xml:"name,attr"`
-	Member      []string `xml:"members>member"`
-	Tag         []string `xml:"tag>member"`  // TODO: Check
-	Description string   `xml:"description"` // TODO: Check
+	Name   string   `xml:"name,attr"`
+	Member []string `xml:"members>member"`
+	Tag    []string `xml:"tag>member"`
 }
 
 // Service is service>entry
@@ -314,50 +370,17 @@ type Security struct {
 	To          []string `xml:"to>member"`
 	Source      []string `xml:"source>member"`
 	Destination []string `xml:"destination>member"`
+	SourceUser  []string `xml:"source-user>member"`
 	Application []string `xml:"application>member"`
 	Service     []string `xml:"service>member"`
 	Action      string   `xml:"action"`
 	Description string   `xml:"description"`
 }
 
-func parseConfig(inFile string) (*Config, *Vsys, error) {
-	var data []byte
-	var err error
-
-	if strings.HasSuffix(inFile, ".tar.gz") || strings.HasSuffix(inFile, ".tgz") {
-		file, err := os.Open(inFile)
-		if err != nil {
-			return nil, nil, err
-		}
-		defer file.Close()
-		gzipReader, err := gzip.NewReader(file)
-		if err != nil {
-			return nil, nil, err
-		}
-		defer gzipReader.Close()
-		tarReader := tar.NewReader(gzipReader)
-		for {
-			header, err := tarReader.Next()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, nil, err
-			}
-
-			if header.Name == "./running-config.xml" {
-				data, err = io.ReadAll(tarReader)
-				if err != nil {
-					return nil, nil, err
-				}
-				break
-			}
-		}
-	} else {
-		data, err = os.ReadFile(inFile)
-		if err != nil {
-			return nil, nil, err
-		}
+func parseConfig(inFile string, opts Options) (*Config, *Vsys, error) {
+	data, err := readConfig(inFile, opts)
+	if err != nil {
+		return nil, nil, err
 	}
 	var config Config
 	if err := xml.Unmarshal(data, &config); err != nil {
@@ -368,12 +391,27 @@ func parseConfig(inFile string) (*Config, *Vsys, error) {
 			return &config, &entry, nil
 		}
 	}
+	if config.isPanorama() {
+		return &config, nil, nil
+	}
 	return nil, nil, fmt.Errorf("vsys1 not found")
 }
 
+// sheetName returns a sheet name with the prefix of the config, which is
+// unique in the workbook.
+func (c *Config) sheetName(name string) string {
+	return c.sheets.Name(c.prefix, name)
+}
+
+// sheetName returns a sheet name with the prefix of the vsys, which is
+// unique in the workbook.
+func (v *Vsys) sheetName(name string) string {
+	return v.sheets.Name(v.prefix, name)
+}
+
 // outputUsers is <zone> output process.
 func outputUsers(xl *excel.Excel, config *Config) error {
-	sheet := "ユーザー"
+	sheet := config.sheetName("ユーザー")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputUsers: %w", err)
 	}
@@ -402,14 +440,16 @@ func outputUsers(xl *excel.Excel, config *Config) error {
 
 // outputEthernet is <ethernet> output process.
 func outputEthernet(xl *excel.Excel, config *Config) error {
-	sheet := "イーサネット"
+	sheet := config.sheetName("イーサネット")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputEthernet: %w", err)
 	}
 	if err := xl.SetHeader([]excel.Header{
 		{"#", 4}, {"名前", 16}, {"集約グループ", 10}, {"ポート優先度", 10},
-		{"リンク状態", 6}, {"IPアドレス", 18}, {"管理プロファイル", 10},
-		{"Netflowプロファイル", 10}, {"LLDP", 8}, {"HA", 4}, {"内容", 60},
+		{"リンク状態", 6}, {"IPアドレス", 18}, {"IPv6アドレス", 26},
+		{"IPv6 ND/RA", 20}, {"管理プロファイル", 10},
+		{"Netflowプロファイル", 10}, {"LLDP", 8}, {"LLDPプロファイル", 10},
+		{"HA", 4}, {"内容", 60},
 	}); err != nil {
 		return fmt.Errorf("outputEthernet: %w", err)
 	}
@@ -425,9 +465,11 @@ func outputEthernet(xl *excel.Excel, config *Config) error {
 		return is[0] < is[1]
 	})
 	for i, e := range entries {
-		err := xl.SetRow(&[]any{i + 1, e.Name, e.AggregateGroup, e.PortPriority, e.LinkState,
-			e.IP, e.InterfaceManagementProfile, e.NetflowProfile,
-			e.LLDPEnable, e.HA, e.Comment})
+		err := xl.SetRow(&[]any{i + 1, e.Name, markAggregateGroup(config, e.AggregateGroup),
+			e.PortPriority, e.LinkState,
+			e.IP, e.IPv6, neighborDiscovery(e.IPv6),
+			e.InterfaceManagementProfile, markNetflowProfile(config, e.NetflowProfile),
+			e.LLDPEnable, markLLDPProfile(config, e.LLDPProfile), e.HA, e.Comment})
 		if err != nil {
 			return fmt.Errorf("outputEthernet: %w", err)
 		}
@@ -440,7 +482,7 @@ func outputEthernet(xl *excel.Excel, config *Config) error {
 
 // outputZone() is <zone> output process.
 func outputZone(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "ゾーン"
+	sheet := vsys1.sheetName("ゾーン")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputZone: %w", err)
 	}
@@ -456,9 +498,19 @@ func outputZone(xl *excel.Excel, vsys1 *Vsys) error {
 	for i, e := range entries {
 		typ := ""
 		var member []string
-		if e.Layer3 != nil {
+		switch {
+		case e.Layer3 != nil:
 			typ = "Layer3"
 			member = e.Layer3
+		case e.Layer2 != nil:
+			typ = "Layer2"
+			member = e.Layer2
+		case e.VirtualWire != nil:
+			typ = "Virtual Wire"
+			member = e.VirtualWire
+		case e.Tap != nil:
+			typ = "Tap"
+			member = e.Tap
 		}
 		err := xl.SetRow(&[]any{i + 1, e.Name, typ, member, e.Description})
 		if err != nil {
@@ -473,7 +525,7 @@ func outputZone(xl *excel.Excel, vsys1 *Vsys) error {
 
 // outputVirtualRouterInterface() is <interface> output process.
 func outputVirtualRouterInterface(xl *excel.Excel, config *Config) error {
-	sheet := "VRインターフェイス"
+	sheet := config.sheetName("VRインターフェイス")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputVirtualRouterInterface: %w", err)
 	}
@@ -505,12 +557,12 @@ func outputVirtualRouterInterface(xl *excel.Excel, config *Config) error {
 
 // outputVirtualRouterStaticRoute() is <static-route> output process.
 func outputVirtualRouterStaticRoute(xl *excel.Excel, config *Config) error {
-	sheet := "VRスタティックルート"
+	sheet := config.sheetName("VRスタティックルート")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputVirtualRouterStaticRoute: %w", err)
 	}
 	if err := xl.SetHeader([]excel.Header{
-		{"#", 4}, {"Virtual Router", 20}, {"名前", 20}, {"宛先", 20},
+		{"#", 4}, {"Virtual Router", 20}, {"種別", 6}, {"名前", 20}, {"宛先", 20},
 		{"インターフェイス", 20}, {"Nexthop", 14}, {"メトリック", 4}, {"Bfd", 10},
 	}); err != nil {
 		return fmt.Errorf("outputVirtualRouterStaticRoute: %w", err)
@@ -524,8 +576,16 @@ func outputVirtualRouterStaticRoute(xl *excel.Excel, config *Config) error {
 		// TODO: sort of StaticRoute
 		for _, e2 := range e.StaticRoute {
 			r++
-			err := xl.SetRow(&[]any{r, e.Name, e2.Name, e2.Destination, e2.Interface, e2.Nexthop,
-				e2.Metric, e2.Bfd})
+			err := xl.SetRow(&[]any{r, e.Name, "IPv4", e2.Name, e2.Destination, e2.Interface,
+				e2.Nexthop, e2.Metric, e2.Bfd})
+			if err != nil {
+				return fmt.Errorf("outputVirtualRouterStaticRoute: %w", err)
+			}
+		}
+		for _, e2 := range e.StaticRouteIPv6 {
+			r++
+			err := xl.SetRow(&[]any{r, e.Name, "IPv6", e2.Name, e2.Destination, e2.Interface,
+				e2.NexthopIPv6, e2.Metric, e2.Bfd})
 			if err != nil {
 				return fmt.Errorf("outputVirtualRouterStaticRoute: %w", err)
 			}
@@ -539,7 +599,7 @@ func outputVirtualRouterStaticRoute(xl *excel.Excel, config *Config) error {
 
 // outputTag() is <tag> output process.
 func outputTag(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "タグ"
+	sheet := vsys1.sheetName("タグ")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputTag: %w", err)
 	}
@@ -571,12 +631,13 @@ func outputTag(xl *excel.Excel, vsys1 *Vsys) error {
 
 // outputAddress() is <address> output process.
 func outputAddress(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "アドレス"
+	sheet := vsys1.sheetName("アドレス")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputAddress: %w", err)
 	}
 	if err := xl.SetHeader([]excel.Header{
-		{"#", 4}, {"名前", 20}, {"アドレス", 20}, {"タグ", 12}, {"内容", 60},
+		{"#", 4}, {"名前", 20}, {"種別", 6}, {"アドレス", 20}, {"タグ", 12},
+		{"内容", 60},
 	}); err != nil {
 		return fmt.Errorf("outputAddress: %w", err)
 	}
@@ -588,11 +649,13 @@ func outputAddress(xl *excel.Excel, vsys1 *Vsys) error {
 		content := ""
 		if e.IPNetmask != "" {
 			content = e.IPNetmask
+		} else if e.IPRange != "" {
+			content = e.IPRange
 		} else if e.FQDN != "" {
 			content = e.FQDN
 		}
 		err := xl.SetRow(&[]any{
-			i + 1, e.Name, content, e.Tag, e.Description})
+			i + 1, e.Name, addressFamily(e), content, e.Tag, e.Description})
 		if err != nil {
 			return fmt.Errorf("outputAddress: %w", err)
 		}
@@ -605,7 +668,7 @@ func outputAddress(xl *excel.Excel, vsys1 *Vsys) error {
 
 // outputAddressGroup() is <address-group> output process.
 func outputAddressGroup(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "アドレスグループ"
+	sheet := vsys1.sheetName("アドレスグループ")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputAddressGroup: %w", err)
 	}
@@ -632,13 +695,14 @@ func outputAddressGroup(xl *excel.Excel, vsys1 *Vsys) error {
 }
 
 // outputApplicationGroup() is <application-group> output process.
-func outputApplicationGroup(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "アプリケーショングループ"
+func outputApplicationGroup(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("アプリケーショングループ")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputApplicationGroup: %w", err)
 	}
 	if err := xl.SetHeader([]excel.Header{
-		{"#", 4}, {"名前", 20}, {"メンバー", 60}, {"タグ", 12}, {"内容", 60},
+		{"#", 4}, {"名前", 20}, {"メンバー", 60}, {"メンバー種類", 40},
+		{"タグ", 12},
 	}); err != nil {
 		return fmt.Errorf("outputApplicationGroup: %w", err)
 	}
@@ -647,8 +711,12 @@ func outputApplicationGroup(xl *excel.Excel, vsys1 *Vsys) error {
 		return entries[i].Name < entries[j].Name
 	})
 	for i, e := range entries {
+		var kind []string
+		for _, member := range e.Member {
+			kind = append(kind, member+": "+applicationKind(config, vsys1, member))
+		}
 		err := xl.SetRow(&[]any{
-			i + 1, e.Name, e.Member, e.Tag, e.Description})
+			i + 1, e.Name, e.Member, kind, e.Tag})
 		if err != nil {
 			return fmt.Errorf("outputApplicationGroup: %w", err)
 		}
@@ -661,7 +729,7 @@ func outputApplicationGroup(xl *excel.Excel, vsys1 *Vsys) error {
 
 // outputService() is <service> output process.
 func outputService(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "サービス"
+	sheet := vsys1.sheetName("サービス")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputService: %w", err)
 	}
@@ -703,7 +771,7 @@ func outputService(xl *excel.Excel, vsys1 *Vsys) error {
 
 // outputServiceGroup() is <service-group> output process.
 func outputServiceGroup(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "サービスグループ"
+	sheet := vsys1.sheetName("サービスグループ")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputServiceGroup: %w", err)
 	}
@@ -730,24 +798,25 @@ func outputServiceGroup(xl *excel.Excel, vsys1 *Vsys) error {
 }
 
 // outputSecurity() is <security> output process.
-func outputSecurity(xl *excel.Excel, vsys1 *Vsys) error {
-	sheet := "セキュリティ"
+func outputSecurity(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("セキュリティ")
 	if err := xl.NewSheet(sheet); err != nil {
 		return fmt.Errorf("outputSecurity: %w", err)
 	}
 	if err := xl.SetHeader([]excel.Header{
 		{"#", 4}, {"名前", 20}, {"送信元ゾーン", 10},
 		{"宛先ゾーン", 10}, {"送信元", 30}, {"宛先", 30},
-		{"アプリケーション", 30}, {"サービス", 30}, {"アクション", 10},
-		{"内容", 60},
+		{"送信元ユーザー", 20}, {"アプリケーション", 30}, {"サービス", 30},
+		{"アクション", 10}, {"内容", 60},
 	}); err != nil {
 		return fmt.Errorf("outputSecurity: %w", err)
 	}
 	entries := vsys1.Security
 	for i, e := range entries {
 		err := xl.SetRow(&[]any{
-			i + 1, e.Name, e.From, e.To, e.Source, e.Destination, e.Application,
-			e.Service, e.Action, e.Description})
+			i + 1, e.Name, e.From, e.To, markEDL(config, vsys1, e.Source),
+			markEDL(config, vsys1, e.Destination), markGroupMapping(vsys1, e.SourceUser),
+			e.Application, e.Service, e.Action, e.Description})
 		if err != nil {
 			return fmt.Errorf("outputSecurity: %w", err)
 		}
@@ -764,6 +833,8 @@ func writeExcel(outFile string, config *Config, vsys1 *Vsys) error {
 	if err != nil {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
+	config.sheets = &sheetname.Set{}
+	vsys1.sheets = config.sheets
 	defer func() {
 		if err := xl.Close(); err != nil {
 			log.Errorf("WriteExcel: %v", err)
@@ -775,11 +846,66 @@ func writeExcel(outFile string, config *Config, vsys1 *Vsys) error {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
 
+	// <deviceconfig><system><service>
+	if err := outputHardening(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <deviceconfig><setting>
+	if err := outputDeviceSetting(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <snmp-setting>
+	if err := outputSNMP(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <log-settings><snmptrap>
+	if err := outputSNMPTrapProfile(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <log-settings><syslog>
+	if err := outputSyslogProfile(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <ssh><profiles>
+	if err := outputSSHServerProfile(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
 	// <ethernet>
 	if err := outputEthernet(xl, config); err != nil {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
 
+	// <aggregate-ethernet>
+	if err := outputAggregateEthernet(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <lldp-profile>
+	if err := outputLLDPProfile(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <server-profile><netflow>
+	if err := outputNetflowProfile(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <virtual-wire>
+	if err := outputVirtualWire(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <vlan>
+	if err := outputVLAN(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
 	// <zone>
 	if err := outputZone(xl, vsys1); err != nil {
 		return fmt.Errorf("WriteExcel: %w", err)
@@ -810,8 +936,38 @@ func writeExcel(outFile string, config *Config, vsys1 *Vsys) error {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
 
+	// <external-list>
+	if err := outputExternalList(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <schedule>
+	if err := outputSchedule(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <region>
+	if err := outputRegion(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <custom-url-category>
+	if err := outputCustomURLCategory(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <application>
+	if err := outputApplication(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <application-filter>
+	if err := outputApplicationFilter(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
 	// <application-group>
-	if err := outputApplicationGroup(xl, vsys1); err != nil {
+	if err := outputApplicationGroup(xl, config, vsys1); err != nil {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
 
@@ -825,8 +981,113 @@ func writeExcel(outFile string, config *Config, vsys1 *Vsys) error {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
 
+	// <user-id-agent>
+	if err := outputUserIDAgent(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <group-mapping>
+	if err := outputGroupMapping(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <local-user-database><user>
+	if err := outputLocalUser(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <local-user-database><user-group>
+	if err := outputLocalUserGroup(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <dynamic-user-group>
+	if err := outputDynamicUserGroup(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <hip-objects>
+	if err := outputHIPObject(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <hip-profiles>
+	if err := outputHIPProfile(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <captive-portal>
+	if err := outputCaptivePortal(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
 	// <security>
-	if err := outputSecurity(xl, vsys1); err != nil {
+	if err := outputSecurity(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <nat>
+	if err := outputNat(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <application-override>
+	if err := outputApplicationOverride(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <sdwan-interface-profile>
+	if err := outputSDWANInterfaceProfile(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <sdwan-path-quality>
+	if err := outputSDWANPathQuality(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <sdwan-traffic-distribution>
+	if err := outputSDWANTrafficDistribution(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <sdwan>
+	if err := outputSDWAN(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <authentication>
+	if err := outputAuthentication(xl, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <certificate>
+	if err := outputCertificate(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <certificate-profile>
+	if err := outputCertificateProfile(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <ssl-tls-service-profile>
+	if err := outputSSLTLSServiceProfile(xl, config, vsys1); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <qos><profile>
+	if err := outputQosProfile(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <qos><interface>
+	if err := outputQosInterface(xl, config); err != nil {
+		return fmt.Errorf("WriteExcel: %w", err)
+	}
+
+	// <qos> rulebase
+	if err := outputQoS(xl, vsys1); err != nil {
 		return fmt.Errorf("WriteExcel: %w", err)
 	}
 
@@ -836,14 +1097,29 @@ func writeExcel(outFile string, config *Config, vsys1 *Vsys) error {
 	return nil
 }
 
+// Options is the options of ConvertPAConfig
+type Options struct {
+	// CertWarningDays is the number of days before expiry at which a
+	// certificate is marked as expiring soon.
+	CertWarningDays int
+	// ArchiveEntry is the name of the config to convert in an archive.
+	// Either the full path or the base name in the archive can be specified.
+	ArchiveEntry string
+	// SelectArchiveEntry chooses one of the config names found in an
+	// archive when ArchiveEntry is empty. If it is nil, the running config
+	// is chosen.
+	SelectArchiveEntry func(names []string) (string, error)
+}
+
 // ConvertPAConfig converts a PaloAlto config to a parameter sheet
-func ConvertPAConfig(inFile, outFile string) error {
+func ConvertPAConfig(inFile, outFile string, opts Options) error {
 	log.Infof("input file: %s\n", inFile)
 	log.Infof("output file: %s\n", outFile)
-	config, vsys1, err := parseConfig(inFile)
+	config, vsys1, err := parseConfig(inFile, opts)
 	if err != nil {
 		return fmt.Errorf("ConvertPAConfig: %w", err)
 	}
+	config.certWarningDays = opts.CertWarningDays
 	log.Infof("The sw-version of the analyzed config is %s (detail: %s)",
 		config.Version, config.DetailVersion)
 	_, err = os.Stat(outFile)
@@ -854,7 +1130,12 @@ func ConvertPAConfig(inFile, outFile string) error {
 			return fmt.Errorf("ConvertPAConfig: %w", err)
 		}
 	}
-	if err = writeExcel(outFile, config, vsys1); err != nil {
+	if vsys1 == nil {
+		log.Info("The analyzed config is a Panorama config")
+		if err = writePanoramaExcel(outFile, config); err != nil {
+			return fmt.Errorf("ConvertPAConfig: %w", err)
+		}
+	} else if err = writeExcel(outFile, config, vsys1); err != nil {
 		return fmt.Errorf("ConvertPAConfig: %w", err)
 	}
 	log.Infof("out put the excel file: %s\n", outFile)
@@ -0,0 +1,189 @@
+package paloalto
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// Application is application>entry
+type Application struct {
+	Name        string   `xml:"name,attr"`
+	Category    string   `xml:"category"`
+	Subcategory string   `xml:"subcategory"`
+	Technology  string   `xml:"technology"`
+	Risk        string   `xml:"risk"`
+	ParentApp   string   `xml:"parent-app"`
+	Port        []string `xml:"default>port>member"`
+	IPProtocol  string   `xml:"default>ident-by-ip-protocol"`
+	Timeout     string   `xml:"timeout"`
+	Tag         []string `xml:"tag>member"`
+	Description string   `xml:"description"`
+}
+
+// ApplicationFilter is application-filter>entry
+type ApplicationFilter struct {
+	Name                  string   `xml:"name,attr"`
+	Category              []string `xml:"category>member"`
+	Subcategory           []string `xml:"subcategory>member"`
+	Technology            []string `xml:"technology>member"`
+	Risk                  []string `xml:"risk>member"`
+	Tagging               []string `xml:"tagging>tag>member"`
+	Evasive               string   `xml:"evasive"`
+	ExcessiveBandwidthUse string   `xml:"excessive-bandwidth-use"`
+	UsedByMalware         string   `xml:"used-by-malware"`
+	TransfersFiles        string   `xml:"transfers-files"`
+	HasKnownVulnerability string   `xml:"has-known-vulnerability"`
+	TunnelsOtherApps      string   `xml:"tunnels-other-apps"`
+	ProneToMisuse         string   `xml:"prone-to-misuse"`
+	PervasiveUse          string   `xml:"pervasive-use"`
+}
+
+// characteristics returns the enabled characteristics of the filter.
+func (a ApplicationFilter) characteristics() []string {
+	var c []string
+	for _, v := range []struct{ name, value string }{
+		{"evasive", a.Evasive},
+		{"excessive-bandwidth-use", a.ExcessiveBandwidthUse},
+		{"used-by-malware", a.UsedByMalware},
+		{"transfers-files", a.TransfersFiles},
+		{"has-known-vulnerability", a.HasKnownVulnerability},
+		{"tunnels-other-apps", a.TunnelsOtherApps},
+		{"prone-to-misuse", a.ProneToMisuse},
+		{"pervasive-use", a.PervasiveUse},
+	} {
+		if v.value == "yes" {
+			c = append(c, v.name)
+		}
+	}
+	return c
+}
+
+// ApplicationOverride is rulebase>application-override>rules>entry
+type ApplicationOverride struct {
+	Name        string   `xml:"name,attr"`
+	From        []string `xml:"from>member"`
+	To          []string `xml:"to>member"`
+	Source      []string `xml:"source>member"`
+	Destination []string `xml:"destination>member"`
+	Protocol    string   `xml:"protocol"`
+	Port        string   `xml:"port"`
+	Application string   `xml:"application"`
+	Disabled    string   `xml:"disabled"`
+	Description string   `xml:"description"`
+}
+
+// applicationKind returns the kind of the application group member. The
+// vsys is looked up first and then the shared.
+func applicationKind(config *Config, vsys1 *Vsys, name string) string {
+	for _, vsys := range []*Vsys{vsys1, &config.Shared.Vsys} {
+		switch {
+		case slices.ContainsFunc(vsys.Application, func(a Application) bool {
+			return a.Name == name
+		}):
+			return "カスタム"
+		case slices.ContainsFunc(vsys.ApplicationFilter, func(a ApplicationFilter) bool {
+			return a.Name == name
+		}):
+			return "フィルタ"
+		case slices.ContainsFunc(vsys.ApplicationGroup, func(a ApplicationGroup) bool {
+			return a.Name == name
+		}):
+			return "グループ"
+		}
+	}
+	return "定義済み"
+}
+
+// outputApplication() is <application> output process.
+func outputApplication(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("カスタムアプリケーション")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputApplication: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"カテゴリ", 16}, {"サブカテゴリ", 16},
+		{"テクノロジー", 16}, {"リスク", 6}, {"親アプリケーション", 16},
+		{"ポート", 20}, {"IPプロトコル", 8}, {"タイムアウト", 8},
+		{"タグ", 12}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputApplication: %w", err)
+	}
+	entries := vsys1.Application
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Category, e.Subcategory, e.Technology, e.Risk,
+			e.ParentApp, e.Port, e.IPProtocol, e.Timeout, e.Tag, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputApplication: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputApplication: %w", err)
+	}
+	return nil
+}
+
+// outputApplicationFilter() is <application-filter> output process.
+func outputApplicationFilter(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("アプリケーションフィルタ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputApplicationFilter: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"カテゴリ", 20}, {"サブカテゴリ", 20},
+		{"テクノロジー", 20}, {"リスク", 10}, {"特性", 30}, {"タグ", 12},
+	}); err != nil {
+		return fmt.Errorf("outputApplicationFilter: %w", err)
+	}
+	entries := vsys1.ApplicationFilter
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Category, e.Subcategory, e.Technology, e.Risk,
+			e.characteristics(), e.Tagging})
+		if err != nil {
+			return fmt.Errorf("outputApplicationFilter: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputApplicationFilter: %w", err)
+	}
+	return nil
+}
+
+// outputApplicationOverride() is <application-override> output process.
+func outputApplicationOverride(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("アプリケーションオーバーライド")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputApplicationOverride: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"送信元ゾーン", 10},
+		{"宛先ゾーン", 10}, {"送信元", 30}, {"宛先", 30},
+		{"プロトコル", 8}, {"ポート", 20}, {"アプリケーション", 20},
+		{"無効", 6}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputApplicationOverride: %w", err)
+	}
+	entries := vsys1.ApplicationOverride
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.From, e.To, e.Source, e.Destination, e.Protocol,
+			e.Port, e.Application, e.Disabled, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputApplicationOverride: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputApplicationOverride: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package paloalto
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestApplicationGroupXML(t *testing.T) {
+	data := `<config><devices><entry name="localhost.localdomain"><vsys><entry name="vsys1">
+<application-group>
+  <entry name="web-apps">
+    <members>
+      <member>web-browsing</member>
+      <member>ssl</member>
+    </members>
+    <tag>
+      <member>web</member>
+    </tag>
+  </entry>
+</application-group>
+</entry></vsys></entry></devices></config>`
+	var config Config
+	if err := xml.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatal(err)
+	}
+	want := []ApplicationGroup{{
+		Name:   "web-apps",
+		Member: []string{"web-browsing", "ssl"},
+		Tag:    []string{"web"},
+	}}
+	if got := config.Vsys[0].ApplicationGroup; !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplicationGroup = %+v, want %+v", got, want)
+	}
+}
+
+func TestApplicationKind(t *testing.T) {
+	config := &Config{}
+	config.Shared.Application = []Application{{Name: "shared-app"}}
+	config.Shared.ApplicationFilter = []ApplicationFilter{{Name: "shared-filter"}}
+	config.Shared.ApplicationGroup = []ApplicationGroup{{Name: "shared-group"}}
+	vsys1 := &Vsys{
+		Application:       []Application{{Name: "custom-app"}},
+		ApplicationFilter: []ApplicationFilter{{Name: "high-risk"}},
+		ApplicationGroup:  []ApplicationGroup{{Name: "web-apps"}},
+	}
+	tests := []struct {
+		name, want string
+	}{
+		{"custom-app", "カスタム"},
+		{"high-risk", "フィルタ"},
+		{"web-apps", "グループ"},
+		{"shared-app", "カスタム"},
+		{"shared-filter", "フィルタ"},
+		{"shared-group", "グループ"},
+		{"web-browsing", "定義済み"},
+	}
+	for _, tt := range tests {
+		if got := applicationKind(config, vsys1, tt.name); got != tt.want {
+			t.Errorf("applicationKind(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package paloalto
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// archiveEntry is a config file found in an archive.
+type archiveEntry struct {
+	name string
+	data []byte
+}
+
+// rank returns the order of the config in the archive.
+func (a archiveEntry) rank() int {
+	switch path.Base(a.name) {
+	case "running-config.xml":
+		return 0
+	case "candidate-config.xml":
+		return 1
+	}
+	return 2
+}
+
+// isConfigEntry reports whether the file in an archive is a config.
+func isConfigEntry(name string) bool {
+	base := path.Base(name)
+	if base == "running-config.xml" || base == "candidate-config.xml" {
+		return true
+	}
+	return path.Base(path.Dir(name)) == "saved-configs" &&
+		strings.EqualFold(path.Ext(base), ".xml")
+}
+
+// readTar reads the configs in a tar archive.
+func readTar(r io.Reader) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || !isConfigEntry(header.Name) {
+			continue
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{header.Name, data})
+	}
+	return entries, nil
+}
+
+// readZip reads the configs in a zip archive.
+func readZip(inFile string) ([]archiveEntry, error) {
+	zipReader, err := zip.OpenReader(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer zipReader.Close()
+	var entries []archiveEntry
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || !isConfigEntry(file.Name) {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{file.Name, data})
+	}
+	return entries, nil
+}
+
+// selectEntry chooses the config to convert from the configs in an archive.
+func selectEntry(inFile string, entries []archiveEntry, opts Options) ([]byte, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf(
+			"no running-config, candidate-config or saved-configs found in %s",
+			inFile)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].rank() != entries[j].rank() {
+			return entries[i].rank() < entries[j].rank()
+		}
+		return entries[i].name < entries[j].name
+	})
+	name := opts.ArchiveEntry
+	if name == "" && len(entries) > 1 && opts.SelectArchiveEntry != nil {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.name)
+		}
+		var err error
+		if name, err = opts.SelectArchiveEntry(names); err != nil {
+			return nil, err
+		}
+	}
+	if name == "" {
+		return entries[0].data, nil
+	}
+	for _, e := range entries {
+		if e.name == name || path.Base(e.name) == name {
+			return e.data, nil
+		}
+	}
+	return nil, fmt.Errorf("%s not found in %s", name, inFile)
+}
+
+// readConfig reads the config from a file. The file may be a plain XML
+// file, a gzip compressed XML file or a tar.gz/tgz/zip archive such as a
+// tech support file.
+func readConfig(inFile string, opts Options) ([]byte, error) {
+	lower := strings.ToLower(inFile)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz"):
+		file, err := os.Open(inFile)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		entries, err := readTar(gzipReader)
+		if err != nil {
+			return nil, err
+		}
+		return selectEntry(inFile, entries, opts)
+	case strings.HasSuffix(lower, ".zip"):
+		entries, err := readZip(inFile)
+		if err != nil {
+			return nil, err
+		}
+		return selectEntry(inFile, entries, opts)
+	case strings.HasSuffix(lower, ".gz"):
+		file, err := os.Open(inFile)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		return io.ReadAll(gzipReader)
+	}
+	return os.ReadFile(inFile)
+}
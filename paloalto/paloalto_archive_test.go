@@ -0,0 +1,183 @@
+package paloalto
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// archiveFiles are the files of a tech support file. The configs are
+// found under any path prefix.
+var archiveFiles = []struct {
+	name, data string
+}{
+	{"tmp/cli/techsupport/opt/pancfg/mgmt/saved-configs/backup.xml", "backup"},
+	{"tmp/cli/techsupport/opt/pancfg/mgmt/saved-configs/candidate-config.xml", "candidate"},
+	{"tmp/cli/techsupport/opt/pancfg/mgmt/saved-configs/running-config.xml", "running"},
+	{"tmp/cli/techsupport/opt/pancfg/mgmt/saved-configs/notes.txt", "notes"},
+	{"tmp/cli/techsupport/var/backup.xml", "not a saved config"},
+}
+
+func writeTgz(t *testing.T, name string, files []struct{ name, data string }) string {
+	t.Helper()
+	inFile := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(inFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, f := range files {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name: f.name, Mode: 0o644, Size: int64(len(f.data)),
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(f.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return inFile
+}
+
+func writeZip(t *testing.T, name string, files []struct{ name, data string }) string {
+	t.Helper()
+	inFile := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(inFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	zipWriter := zip.NewWriter(file)
+	for _, f := range files {
+		w, err := zipWriter.Create(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(f.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return inFile
+}
+
+func TestReadConfig(t *testing.T) {
+	tgz := writeTgz(t, "techsupport.tgz", archiveFiles)
+	targz := writeTgz(t, "techsupport.tar.gz", archiveFiles)
+	zipFile := writeZip(t, "techsupport.zip", archiveFiles)
+	empty := writeTgz(t, "empty.tgz", archiveFiles[3:])
+	emptyZip := writeZip(t, "empty.zip", archiveFiles[3:])
+
+	gz := filepath.Join(t.TempDir(), "running-config.xml.gz")
+	file, err := os.Create(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzipWriter := gzip.NewWriter(file)
+	if _, err := gzipWriter.Write([]byte("plain gzip")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	tests := []struct {
+		name    string
+		inFile  string
+		entry   string
+		want    string
+		wantErr string
+	}{
+		{"tgz running-config", tgz, "", "running", ""},
+		{"tar.gz running-config", targz, "", "running", ""},
+		{"zip running-config", zipFile, "", "running", ""},
+		{"tgz base name", tgz, "candidate-config.xml", "candidate", ""},
+		{"zip saved-configs", zipFile, "backup.xml", "backup", ""},
+		{"tgz full path", tgz, archiveFiles[0].name, "backup", ""},
+		{"tgz not a config", tgz, "notes.txt", "", "notes.txt not found"},
+		{"zip missing entry", zipFile, "missing.xml", "", "missing.xml not found"},
+		{"tgz no config", empty, "", "", "no running-config"},
+		{"zip no config", emptyZip, "", "", "no running-config"},
+		{"gz", gz, "", "plain gzip", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := readConfig(tt.inFile, Options{ArchiveEntry: tt.entry})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("data = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectEntry(t *testing.T) {
+	entries := []archiveEntry{
+		{"b/saved-configs/z.xml", []byte("z")},
+		{"a/candidate-config.xml", []byte("candidate")},
+		{"b/saved-configs/a.xml", []byte("a")},
+		{"c/running-config.xml", []byte("running")},
+	}
+	var names []string
+	opts := Options{SelectArchiveEntry: func(n []string) (string, error) {
+		names = n
+		return "a.xml", nil
+	}}
+	data, err := selectEntry("test.tgz", entries, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"c/running-config.xml", "a/candidate-config.xml",
+		"b/saved-configs/a.xml", "b/saved-configs/z.xml"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	if string(data) != "a" {
+		t.Errorf("data = %q, want %q", data, "a")
+	}
+
+	// the explicit entry takes precedence over SelectArchiveEntry
+	names = nil
+	opts.ArchiveEntry = "candidate-config.xml"
+	if data, err = selectEntry("test.tgz", entries, opts); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "candidate" || names != nil {
+		t.Errorf("data = %q, names = %q", data, names)
+	}
+
+	// a single config is chosen without asking
+	opts = Options{SelectArchiveEntry: func([]string) (string, error) {
+		t.Error("SelectArchiveEntry is called for a single config")
+		return "", nil
+	}}
+	if _, err := selectEntry("test.tgz", entries[:1], opts); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,216 @@
+package paloalto
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// DefaultCertWarningDays is the default of Options.CertWarningDays.
+const DefaultCertWarningDays = 30
+
+// Certificate is certificate>entry
+type Certificate struct {
+	Name           string    `xml:"name,attr"`
+	CommonName     string    `xml:"common-name"`
+	Subject        string    `xml:"subject"`
+	Issuer         string    `xml:"issuer"`
+	NotValidBefore string    `xml:"not-valid-before"`
+	NotValidAfter  string    `xml:"not-valid-after"`
+	ExpiryEpoch    string    `xml:"expiry-epoch"`
+	CA             string    `xml:"ca"`
+	Algorithm      string    `xml:"algorithm"`
+	PrivateKey     *struct{} `xml:"private-key"`
+	PrivateKeyHSM  *struct{} `xml:"private-key-on-hsm"`
+}
+
+// expiry returns the expiry time of the certificate.
+func (c Certificate) expiry() (time.Time, bool) {
+	if epoch, err := strconv.ParseInt(c.ExpiryEpoch, 10, 64); err == nil {
+		return time.Unix(epoch, 0), true
+	}
+	if t, err := time.Parse("Jan _2 15:04:05 2006 MST", c.NotValidAfter); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// hasPrivateKey reports whether the private key is present.
+func (c Certificate) hasPrivateKey() string {
+	switch {
+	case c.PrivateKeyHSM != nil:
+		return "yes (HSM)"
+	case c.PrivateKey != nil:
+		return "yes"
+	}
+	return "no"
+}
+
+// CertificateProfile is certificate-profile>entry
+type CertificateProfile struct {
+	Name          string   `xml:"name,attr"`
+	UsernameField string   `xml:"username-field>subject"`
+	Domain        string   `xml:"domain"`
+	CA            []CertCA `xml:"CA>entry"`
+	UseCRL        string   `xml:"use-crl"`
+	UseOCSP       string   `xml:"use-ocsp"`
+	BlockUnknown  string   `xml:"block-unknown-cert"`
+	BlockTimeout  string   `xml:"block-timeout-cert"`
+	BlockExpired  string   `xml:"block-expired-cert"`
+}
+
+// CertCA is CA>entry
+type CertCA struct {
+	Name          string `xml:"name,attr"`
+	OCSPVerifyURL string `xml:"ocsp-verify-url"`
+}
+
+func (c CertCA) String() string {
+	return c.Name
+}
+
+// SSLTLSServiceProfile is ssl-tls-service-profile>entry
+type SSLTLSServiceProfile struct {
+	Name        string `xml:"name,attr"`
+	Certificate string `xml:"certificate"`
+	MinVersion  string `xml:"protocol-settings>min-version"`
+	MaxVersion  string `xml:"protocol-settings>max-version"`
+}
+
+// outputCertificate() is <certificate> output process.
+func outputCertificate(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("証明書")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputCertificate: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"共通名", 20}, {"発行者", 30},
+		{"有効期間開始", 24}, {"有効期間終了", 24}, {"残り日数", 8},
+		{"状態", 10}, {"CA", 4}, {"アルゴリズム", 8}, {"秘密鍵", 8},
+	}); err != nil {
+		return fmt.Errorf("outputCertificate: %w", err)
+	}
+	now := time.Now()
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []Certificate
+	}{
+		{"shared", config.Shared.Certificate},
+		{vsys1.Name, vsys1.Certificate},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			days := ""
+			status := ""
+			if expiry, ok := e.expiry(); ok {
+				d := int(expiry.Sub(now).Hours() / 24)
+				days = strconv.Itoa(d)
+				switch {
+				case expiry.Before(now):
+					status = "期限切れ"
+				case d <= config.certWarningDays:
+					status = "期限間近"
+				}
+			}
+			err := xl.SetRow(&[]any{r, location.name, e.Name, e.CommonName,
+				e.Issuer, e.NotValidBefore, e.NotValidAfter, days, status, e.CA,
+				e.Algorithm, e.hasPrivateKey()})
+			if err != nil {
+				return fmt.Errorf("outputCertificate: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputCertificate: %w", err)
+	}
+	return nil
+}
+
+// outputCertificateProfile() is <certificate-profile> output process.
+func outputCertificateProfile(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("証明書プロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputCertificateProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"ユーザー名フィールド", 14},
+		{"ドメイン", 14}, {"CA証明書", 30}, {"CRL", 6}, {"OCSP", 6},
+		{"不明な証明書をブロック", 8}, {"タイムアウト時にブロック", 8},
+		{"期限切れ証明書をブロック", 8},
+	}); err != nil {
+		return fmt.Errorf("outputCertificateProfile: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []CertificateProfile
+	}{
+		{"shared", config.Shared.CertificateProfile},
+		{vsys1.Name, vsys1.CertificateProfile},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			err := xl.SetRow(&[]any{r, location.name, e.Name, e.UsernameField,
+				e.Domain, e.CA, e.UseCRL, e.UseOCSP, e.BlockUnknown,
+				e.BlockTimeout, e.BlockExpired})
+			if err != nil {
+				return fmt.Errorf("outputCertificateProfile: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputCertificateProfile: %w", err)
+	}
+	return nil
+}
+
+// outputSSLTLSServiceProfile() is <ssl-tls-service-profile> output process.
+func outputSSLTLSServiceProfile(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("SSL-TLSサービスプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSSLTLSServiceProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"証明書", 20},
+		{"最小バージョン", 10}, {"最大バージョン", 10},
+	}); err != nil {
+		return fmt.Errorf("outputSSLTLSServiceProfile: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []SSLTLSServiceProfile
+	}{
+		{"shared", config.Shared.SSLTLSServiceProfile},
+		{vsys1.Name, vsys1.SSLTLSServiceProfile},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			err := xl.SetRow(&[]any{r, location.name, e.Name, e.Certificate,
+				e.MinVersion, e.MaxVersion})
+			if err != nil {
+				return fmt.Errorf("outputSSLTLSServiceProfile: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSSLTLSServiceProfile: %w", err)
+	}
+	return nil
+}
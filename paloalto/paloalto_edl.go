@@ -0,0 +1,125 @@
+package paloalto
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// ExternalList is external-list>entry
+type ExternalList struct {
+	Name          string     `xml:"name,attr"`
+	IP            *EDLSource `xml:"type>ip"`
+	Domain        *EDLSource `xml:"type>domain"`
+	URL           *EDLSource `xml:"type>url"`
+	PredefinedIP  *EDLSource `xml:"type>predefined-ip"`
+	PredefinedURL *EDLSource `xml:"type>predefined-url"`
+}
+
+// source returns the type and the source of the list.
+func (e ExternalList) source() (string, EDLSource) {
+	switch {
+	case e.IP != nil:
+		return "ip", *e.IP
+	case e.Domain != nil:
+		return "domain", *e.Domain
+	case e.URL != nil:
+		return "url", *e.URL
+	case e.PredefinedIP != nil:
+		return "predefined-ip", *e.PredefinedIP
+	case e.PredefinedURL != nil:
+		return "predefined-url", *e.PredefinedURL
+	}
+	return "", EDLSource{}
+}
+
+// EDLSource is type>ip, type>domain, type>url, ...
+type EDLSource struct {
+	URL                string       `xml:"url"`
+	Recurring          EDLRecurring `xml:"recurring"`
+	CertificateProfile string       `xml:"certificate-profile"`
+	ExceptionList      []string     `xml:"exception-list>member"`
+	Description        string       `xml:"description"`
+}
+
+// EDLRecurring is recurring
+type EDLRecurring struct {
+	FiveMinute *struct{} `xml:"five-minute"`
+	Hourly     *struct{} `xml:"hourly"`
+	Daily      *struct {
+		At string `xml:"at"`
+	} `xml:"daily"`
+	Weekly *struct {
+		DayOfWeek string `xml:"day-of-week"`
+		At        string `xml:"at"`
+	} `xml:"weekly"`
+	Monthly *struct {
+		DayOfMonth string `xml:"day-of-month"`
+		At         string `xml:"at"`
+	} `xml:"monthly"`
+}
+
+func (r EDLRecurring) String() string {
+	switch {
+	case r.FiveMinute != nil:
+		return "five-minute"
+	case r.Hourly != nil:
+		return "hourly"
+	case r.Daily != nil:
+		return fmt.Sprintf("daily %s", r.Daily.At)
+	case r.Weekly != nil:
+		return fmt.Sprintf("weekly %s %s", r.Weekly.DayOfWeek, r.Weekly.At)
+	case r.Monthly != nil:
+		return fmt.Sprintf("monthly %s %s", r.Monthly.DayOfMonth, r.Monthly.At)
+	}
+	return ""
+}
+
+// markEDL marks the members that are external dynamic lists in the vsys or
+// the shared.
+func markEDL(config *Config, vsys1 *Vsys, members []string) []string {
+	marked := make([]string, 0, len(members))
+	for _, member := range members {
+		isEDL := func(e ExternalList) bool { return e.Name == member }
+		if slices.ContainsFunc(vsys1.ExternalList, isEDL) ||
+			slices.ContainsFunc(config.Shared.ExternalList, isEDL) {
+			member += " (EDL)"
+		}
+		marked = append(marked, member)
+	}
+	return marked
+}
+
+// outputExternalList() is <external-list> output process.
+func outputExternalList(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("外部動的リスト")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputExternalList: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 10}, {"ソース", 40},
+		{"更新間隔", 14}, {"証明書プロファイル", 16}, {"除外リスト", 30},
+		{"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputExternalList: %w", err)
+	}
+	entries := vsys1.ExternalList
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		typ, src := e.source()
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, typ, src.URL, src.Recurring, src.CertificateProfile,
+			src.ExceptionList, src.Description})
+		if err != nil {
+			return fmt.Errorf("outputExternalList: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputExternalList: %w", err)
+	}
+	return nil
+}
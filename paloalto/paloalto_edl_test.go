@@ -0,0 +1,20 @@
+package paloalto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarkEDL(t *testing.T) {
+	config := &Config{}
+	config.Shared.ExternalList = []ExternalList{{Name: "shared-edl"}}
+	vsys1 := &Vsys{ExternalList: []ExternalList{{Name: "vsys-edl"}}}
+	got := markEDL(config, vsys1, []string{"vsys-edl", "shared-edl", "10.0.0.0/8", "any"})
+	want := []string{"vsys-edl (EDL)", "shared-edl (EDL)", "10.0.0.0/8", "any"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("markEDL() = %q, want %q", got, want)
+	}
+	if got := markEDL(config, vsys1, nil); len(got) != 0 {
+		t.Errorf("markEDL(nil) = %q, want empty", got)
+	}
+}
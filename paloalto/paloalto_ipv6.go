@@ -0,0 +1,133 @@
+package paloalto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EthernetIPv6 is layer3>ipv6
+type EthernetIPv6 struct {
+	Enabled           string                `xml:"enabled"`
+	InterfaceID       string                `xml:"interface-id"`
+	Address           []EthernetIPv6Address `xml:"address>entry"`
+	NeighborDiscovery NeighborDiscovery     `xml:"neighbor-discovery"`
+}
+
+func (e *EthernetIPv6) String() string {
+	if e == nil || e.Enabled != "yes" {
+		return ""
+	}
+	s := make([]string, 0, len(e.Address))
+	for _, a := range e.Address {
+		s = append(s, a.String())
+	}
+	return strings.Join(s, "\n")
+}
+
+// EthernetIPv6Address is ipv6>address>entry
+type EthernetIPv6Address struct {
+	Name              string    `xml:"name,attr"`
+	EnableOnInterface string    `xml:"enable-on-interface"`
+	Prefix            *struct{} `xml:"prefix"`
+	Anycast           *struct{} `xml:"anycast"`
+	Advertise         string    `xml:"advertise>enable"`
+}
+
+func (a EthernetIPv6Address) String() string {
+	var opts []string
+	if a.EnableOnInterface == "no" {
+		opts = append(opts, "無効")
+	}
+	if a.Prefix != nil {
+		opts = append(opts, "prefix")
+	}
+	if a.Anycast != nil {
+		opts = append(opts, "anycast")
+	}
+	if a.Advertise == "yes" {
+		opts = append(opts, "advertise")
+	}
+	if len(opts) == 0 {
+		return a.Name
+	}
+	return fmt.Sprintf("%s (%s)", a.Name, strings.Join(opts, ", "))
+}
+
+// NeighborDiscovery is ipv6>neighbor-discovery
+type NeighborDiscovery struct {
+	EnableDAD     string              `xml:"enable-dad"`
+	DADAttempts   string              `xml:"dad-attempts"`
+	NSInterval    string              `xml:"ns-interval"`
+	ReachableTime string              `xml:"reachable-time"`
+	RA            RouterAdvertisement `xml:"router-advertisement"`
+}
+
+func (n NeighborDiscovery) String() string {
+	var s []string
+	if n.EnableDAD == "yes" {
+		s = append(s, "DAD")
+	}
+	for _, v := range []struct{ name, value string }{
+		{"dad-attempts", n.DADAttempts},
+		{"ns-interval", n.NSInterval},
+		{"reachable-time", n.ReachableTime},
+	} {
+		if v.value != "" {
+			s = append(s, v.name+"="+v.value)
+		}
+	}
+	if ra := n.RA.String(); ra != "" {
+		s = append(s, ra)
+	}
+	return strings.Join(s, "\n")
+}
+
+// RouterAdvertisement is neighbor-discovery>router-advertisement
+type RouterAdvertisement struct {
+	Enable      string `xml:"enable"`
+	MinInterval string `xml:"min-interval"`
+	MaxInterval string `xml:"max-interval"`
+	Lifetime    string `xml:"lifetime"`
+	ManagedFlag string `xml:"managed-flag"`
+	OtherFlag   string `xml:"other-flag"`
+}
+
+func (r RouterAdvertisement) String() string {
+	if r.Enable != "yes" {
+		return ""
+	}
+	s := []string{"RA"}
+	for _, v := range []struct{ name, value string }{
+		{"min-interval", r.MinInterval},
+		{"max-interval", r.MaxInterval},
+		{"lifetime", r.Lifetime},
+		{"managed-flag", r.ManagedFlag},
+		{"other-flag", r.OtherFlag},
+	} {
+		if v.value != "" {
+			s = append(s, v.name+"="+v.value)
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+// neighborDiscovery returns ND/RA settings when IPv6 is enabled.
+func neighborDiscovery(e *EthernetIPv6) string {
+	if e == nil || e.Enabled != "yes" {
+		return ""
+	}
+	return e.NeighborDiscovery.String()
+}
+
+// addressFamily returns IPv4, IPv6 or FQDN for an address object.
+func addressFamily(e Address) string {
+	switch {
+	case e.FQDN != "":
+		return "FQDN"
+	case strings.Contains(e.IPNetmask+e.IPRange, ":"):
+		return "IPv6"
+	case e.IPNetmask != "" || e.IPRange != "":
+		return "IPv4"
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package paloalto
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// VirtualWire is devices>entry>network>virtual-wire>entry
+type VirtualWire struct {
+	Name                 string `xml:"name,attr"`
+	Interface1           string `xml:"interface1"`
+	Interface2           string `xml:"interface2"`
+	TagAllowed           string `xml:"tag-allowed"`
+	MulticastFirewalling string `xml:"multicast-firewalling>enable"`
+	LinkStatePassThrough string `xml:"link-state-pass-through>enable"`
+}
+
+// VLAN is devices>entry>network>vlan>entry
+type VLAN struct {
+	Name             string    `xml:"name,attr"`
+	Interface        []string  `xml:"interface>member"`
+	VirtualInterface string    `xml:"virtual-interface>interface"`
+	L3Forwarding     string    `xml:"virtual-interface>l3-forwarding"`
+	MAC              []VLANMAC `xml:"mac>entry"`
+}
+
+// VLANMAC is mac>entry
+type VLANMAC struct {
+	Name      string `xml:"name,attr"`
+	Interface string `xml:"interface"`
+}
+
+func (v VLANMAC) String() string {
+	return v.Name + " " + v.Interface
+}
+
+// outputVirtualWire() is <virtual-wire> output process.
+func outputVirtualWire(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("バーチャルワイヤー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputVirtualWire: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"インターフェイス1", 16}, {"インターフェイス2", 16},
+		{"許可されたタグ", 16}, {"マルチキャストファイアウォール", 8},
+		{"リンク状態パススルー", 8},
+	}); err != nil {
+		return fmt.Errorf("outputVirtualWire: %w", err)
+	}
+	entries := config.VirtualWire
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{i + 1, e.Name, e.Interface1, e.Interface2,
+			e.TagAllowed, e.MulticastFirewalling, e.LinkStatePassThrough})
+		if err != nil {
+			return fmt.Errorf("outputVirtualWire: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputVirtualWire: %w", err)
+	}
+	return nil
+}
+
+// outputVLAN() is <vlan> output process.
+func outputVLAN(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("VLAN")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputVLAN: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"インターフェイス", 30},
+		{"VLANインターフェイス", 16}, {"L3転送", 6}, {"スタティックMAC", 40},
+	}); err != nil {
+		return fmt.Errorf("outputVLAN: %w", err)
+	}
+	entries := config.VLAN
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{i + 1, e.Name, e.Interface, e.VirtualInterface,
+			e.L3Forwarding, e.MAC})
+		if err != nil {
+			return fmt.Errorf("outputVLAN: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputVLAN: %w", err)
+	}
+	return nil
+}
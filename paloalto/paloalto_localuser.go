@@ -0,0 +1,306 @@
+package paloalto
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// LocalUser is local-user-database>user>entry
+type LocalUser struct {
+	Name     string `xml:"name,attr"`
+	Phash    string `xml:"phash"`
+	Disabled string `xml:"disabled"`
+}
+
+// LocalUserGroup is local-user-database>user-group>entry
+type LocalUserGroup struct {
+	Name string   `xml:"name,attr"`
+	User []string `xml:"user>member"`
+}
+
+// DynamicUserGroup is dynamic-user-group>entry
+type DynamicUserGroup struct {
+	Name        string   `xml:"name,attr"`
+	Filter      string   `xml:"filter"`
+	Tag         []string `xml:"tag>member"`
+	Description string   `xml:"description"`
+}
+
+// HIPObject is hip-objects>entry
+type HIPObject struct {
+	Name        string        `xml:"name,attr"`
+	Description string        `xml:"description"`
+	Criteria    []HIPCriteria `xml:",any"`
+}
+
+// HIPCriteria is hip-objects>entry>host-info, anti-malware, ...
+// Criteria holds "name=value" of each criteria and vendor element, e.g.
+// "os=contains Microsoft All" or "virdef-version=within days 7".
+type HIPCriteria struct {
+	Category string
+	Criteria []string
+}
+
+// UnmarshalXML reads the category element of a HIP object. Each child of
+// <criteria> and each other child such as <vendor> becomes a "name=value".
+func (h *HIPCriteria) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	h.Category = start.Name.Local
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "criteria" {
+				v, err := hipValue(d)
+				if err != nil {
+					return err
+				}
+				h.Criteria = append(h.Criteria, t.Name.Local+"="+v)
+				continue
+			}
+			for {
+				tok, err := d.Token()
+				if err != nil {
+					return err
+				}
+				if c, ok := tok.(xml.StartElement); ok {
+					v, err := hipValue(d)
+					if err != nil {
+						return err
+					}
+					h.Criteria = append(h.Criteria, c.Name.Local+"="+v)
+				} else if _, ok := tok.(xml.EndElement); ok {
+					break
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// hipValue reads the element until its end. The text and the children are
+// rendered as "name value", and siblings are separated by ", ".
+func hipValue(d *xml.Decoder) (string, error) {
+	var parts []string
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			v, err := hipValue(d)
+			if err != nil {
+				return "", err
+			}
+			label := t.Name.Local
+			switch label {
+			case "member":
+				label = ""
+			case "entry":
+				for _, a := range t.Attr {
+					if a.Name.Local == "name" {
+						label = a.Value
+					}
+				}
+			}
+			parts = append(parts, strings.TrimSpace(label+" "+v))
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if s := strings.TrimSpace(text.String()); s != "" {
+				parts = append(parts, s)
+			}
+			return strings.Join(parts, ", "), nil
+		}
+	}
+}
+
+func (h HIPCriteria) String() string {
+	return h.Category + ": " + strings.Join(h.Criteria, "; ")
+}
+
+// HIPProfile is hip-profiles>entry
+type HIPProfile struct {
+	Name        string `xml:"name,attr"`
+	Match       string `xml:"match"`
+	Description string `xml:"description"`
+}
+
+// outputLocalUser() is <local-user-database><user> output process.
+func outputLocalUser(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("ローカルユーザー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputLocalUser: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"パスワード", 20}, {"無効", 6},
+		{"グループ", 30},
+	}); err != nil {
+		return fmt.Errorf("outputLocalUser: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []LocalUser
+		groups  []LocalUserGroup
+	}{
+		{"shared", config.Shared.LocalUser, config.Shared.LocalUserGroup},
+		{vsys1.Name, vsys1.LocalUser, vsys1.LocalUserGroup},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			var groups []string
+			for _, g := range location.groups {
+				for _, user := range g.User {
+					if user == e.Name {
+						groups = append(groups, g.Name)
+						break
+					}
+				}
+			}
+			err := xl.SetRow(&[]any{r, location.name, e.Name, redact(e.Phash),
+				e.Disabled, groups})
+			if err != nil {
+				return fmt.Errorf("outputLocalUser: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputLocalUser: %w", err)
+	}
+	return nil
+}
+
+// outputLocalUserGroup() is <local-user-database><user-group> output process.
+func outputLocalUserGroup(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("ローカルユーザーグループ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputLocalUserGroup: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"ユーザー", 60},
+	}); err != nil {
+		return fmt.Errorf("outputLocalUserGroup: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []LocalUserGroup
+	}{
+		{"shared", config.Shared.LocalUserGroup},
+		{vsys1.Name, vsys1.LocalUserGroup},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			err := xl.SetRow(&[]any{r, location.name, e.Name, e.User})
+			if err != nil {
+				return fmt.Errorf("outputLocalUserGroup: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputLocalUserGroup: %w", err)
+	}
+	return nil
+}
+
+// outputDynamicUserGroup() is <dynamic-user-group> output process.
+func outputDynamicUserGroup(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("動的ユーザーグループ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputDynamicUserGroup: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"一致条件", 40}, {"タグ", 12}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputDynamicUserGroup: %w", err)
+	}
+	entries := vsys1.DynamicUserGroup
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Filter, e.Tag, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputDynamicUserGroup: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputDynamicUserGroup: %w", err)
+	}
+	return nil
+}
+
+// outputHIPObject() is <hip-objects> output process.
+func outputHIPObject(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("HIPオブジェクト")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputHIPObject: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"一致条件", 60}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputHIPObject: %w", err)
+	}
+	entries := vsys1.HIPObject
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Criteria, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputHIPObject: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputHIPObject: %w", err)
+	}
+	return nil
+}
+
+// outputHIPProfile() is <hip-profiles> output process.
+func outputHIPProfile(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("HIPプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputHIPProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"一致条件", 60}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputHIPProfile: %w", err)
+	}
+	entries := vsys1.HIPProfile
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Match, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputHIPProfile: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputHIPProfile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package paloalto
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestHIPCriteria(t *testing.T) {
+	tests := []struct {
+		name   string
+		xml    string
+		want   HIPCriteria
+		string string
+	}{
+		{
+			name: "nested operator",
+			xml: `<host-info><criteria>
+  <os><contains><Microsoft>All</Microsoft></contains></os>
+  <domain><is>example.com</is></domain>
+</criteria></host-info>`,
+			want: HIPCriteria{"host-info", []string{
+				"os=contains Microsoft All", "domain=is example.com"}},
+			string: "host-info: os=contains Microsoft All; domain=is example.com",
+		},
+		{
+			name: "vendor entry and members",
+			xml: `<anti-malware>
+  <criteria>
+    <virdef-version><within><days>7</days></within></virdef-version>
+    <is-installed>yes</is-installed>
+  </criteria>
+  <vendor>
+    <entry name="Microsoft Corporation">
+      <product><member>Windows Defender</member><member>Defender ATP</member></product>
+    </entry>
+  </vendor>
+</anti-malware>`,
+			want: HIPCriteria{"anti-malware", []string{
+				"virdef-version=within days 7", "is-installed=yes",
+				"vendor=Microsoft Corporation product Windows Defender, Defender ATP"}},
+			string: "anti-malware: virdef-version=within days 7; is-installed=yes; " +
+				"vendor=Microsoft Corporation product Windows Defender, Defender ATP",
+		},
+		{
+			name:   "empty criteria",
+			xml:    `<disk-encryption><criteria/></disk-encryption>`,
+			want:   HIPCriteria{Category: "disk-encryption"},
+			string: "disk-encryption: ",
+		},
+		{
+			name: "empty element",
+			xml: `<patch-management><criteria>
+  <is-installed>yes</is-installed><missing-patches><check>has-any</check><severity/></missing-patches>
+</criteria></patch-management>`,
+			want: HIPCriteria{"patch-management", []string{
+				"is-installed=yes", "missing-patches=check has-any, severity"}},
+			string: "patch-management: is-installed=yes; missing-patches=check has-any, severity",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got HIPCriteria
+			if err := xml.Unmarshal([]byte(tt.xml), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if s := got.String(); s != tt.string {
+				t.Errorf("String() = %q, want %q", s, tt.string)
+			}
+		})
+	}
+}
+
+func TestHIPObjectXML(t *testing.T) {
+	data := `<hip-objects><entry name="corp-pc">
+  <host-info><criteria><managed>yes</managed></criteria></host-info>
+  <firewall><criteria><is-enabled>yes</is-enabled></criteria></firewall>
+  <description>managed PCs</description>
+</entry></hip-objects>`
+	var got struct {
+		HIPObject []HIPObject `xml:"entry"`
+	}
+	if err := xml.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []HIPObject{{
+		Name:        "corp-pc",
+		Description: "managed PCs",
+		Criteria: []HIPCriteria{
+			{"host-info", []string{"managed=yes"}},
+			{"firewall", []string{"is-enabled=yes"}},
+		},
+	}}
+	if !reflect.DeepEqual(got.HIPObject, want) {
+		t.Errorf("HIPObject = %+v, want %+v", got.HIPObject, want)
+	}
+}
@@ -0,0 +1,465 @@
+package paloalto
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// ElementNames is the list of the names of child elements such as
+// <ciphers><aes128-ctr/><aes256-ctr/></ciphers>. The text of <member>
+// elements is used instead of the name.
+type ElementNames []string
+
+func (e *ElementNames) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "member" {
+				var member string
+				if err := d.DecodeElement(&member, &t); err != nil {
+					return err
+				}
+				*e = append(*e, member)
+				continue
+			}
+			*e = append(*e, t.Name.Local)
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// DeviceConfigSystem is devices>entry>deviceconfig>system
+type DeviceConfigSystem struct {
+	Hostname               string             `xml:"hostname"`
+	DisableHTTP            string             `xml:"service>disable-http"`
+	DisableHTTPS           string             `xml:"service>disable-https"`
+	DisableTelnet          string             `xml:"service>disable-telnet"`
+	DisableSSH             string             `xml:"service>disable-ssh"`
+	DisableSNMP            string             `xml:"service>disable-snmp"`
+	DisableHTTPOCSP        string             `xml:"service>disable-http-ocsp"`
+	DisableUserIDService   string             `xml:"service>disable-userid-service"`
+	DisableUserIDSyslogSSL string             `xml:"service>disable-userid-syslog-listener-ssl"`
+	DisableUserIDSyslogUDP string             `xml:"service>disable-userid-syslog-listener-udp"`
+	PermittedIP            []PermittedIP      `xml:"permitted-ip>entry"`
+	PrimaryNTPServer       string             `xml:"ntp-servers>primary-ntp-server>ntp-server-address"`
+	SecondaryNTPServer     string             `xml:"ntp-servers>secondary-ntp-server>ntp-server-address"`
+	SNMPSetting            SNMPSetting        `xml:"snmp-setting"`
+	SSHServerProfile       []SSHServerProfile `xml:"ssh>profiles>mgmt-profiles>server-profiles>entry"`
+}
+
+// PermittedIP is permitted-ip>entry
+type PermittedIP struct {
+	Name string `xml:"name,attr"`
+}
+
+func (p PermittedIP) String() string {
+	return p.Name
+}
+
+// SNMPSetting is snmp-setting
+type SNMPSetting struct {
+	Location     string  `xml:"snmp-system>location"`
+	Contact      string  `xml:"snmp-system>contact"`
+	V2cCommunity *string `xml:"access-setting>version>v2c>snmp-community-string"`
+	V3           *SNMPv3 `xml:"access-setting>version>v3"`
+}
+
+// version returns the SNMP version.
+func (s SNMPSetting) version() string {
+	switch {
+	case s.V3 != nil:
+		return "v3"
+	case s.V2cCommunity != nil:
+		return "v2c"
+	}
+	return ""
+}
+
+// SNMPv3 is access-setting>version>v3
+type SNMPv3 struct {
+	Views []SNMPView `xml:"views>entry"`
+	Users []SNMPUser `xml:"users>entry"`
+}
+
+// SNMPView is views>entry
+type SNMPView struct {
+	Name string          `xml:"name,attr"`
+	View []SNMPViewEntry `xml:"view>entry"`
+}
+
+// SNMPViewEntry is view>entry
+type SNMPViewEntry struct {
+	Name   string `xml:"name,attr"`
+	OID    string `xml:"oid"`
+	Option string `xml:"option"`
+	Mask   string `xml:"mask"`
+}
+
+// SNMPUser is users>entry
+type SNMPUser struct {
+	Name      string `xml:"name,attr"`
+	View      string `xml:"view"`
+	AuthPwd   string `xml:"authpwd"`
+	PrivPwd   string `xml:"privpwd"`
+	AuthProto string `xml:"authproto"`
+	PrivProto string `xml:"privproto"`
+}
+
+// SSHServerProfile is ssh>profiles>mgmt-profiles>server-profiles>entry
+type SSHServerProfile struct {
+	Name    string       `xml:"name,attr"`
+	Ciphers ElementNames `xml:"ciphers"`
+	KEX     ElementNames `xml:"kex"`
+	MAC     ElementNames `xml:"mac"`
+}
+
+// PasswordComplexity is mgt-config>password-complexity
+type PasswordComplexity struct {
+	Enabled          string `xml:"enabled"`
+	MinimumLength    string `xml:"minimum-length"`
+	ExpirationPeriod string `xml:"password-change>expiration-period"`
+}
+
+// SyslogProfile is log-settings>syslog>entry
+type SyslogProfile struct {
+	Name   string         `xml:"name,attr"`
+	Server []SyslogServer `xml:"server>entry"`
+}
+
+// SyslogServer is server>entry
+type SyslogServer struct {
+	Name      string `xml:"name,attr"`
+	Server    string `xml:"server"`
+	Transport string `xml:"transport"`
+	Port      string `xml:"port"`
+	Format    string `xml:"format"`
+	Facility  string `xml:"facility"`
+}
+
+// SNMPTrapProfile is log-settings>snmptrap>entry
+type SNMPTrapProfile struct {
+	Name string           `xml:"name,attr"`
+	V2c  []SNMPTrapServer `xml:"version>v2c>server>entry"`
+	V3   []SNMPTrapServer `xml:"version>v3>server>entry"`
+}
+
+// SNMPTrapServer is server>entry
+type SNMPTrapServer struct {
+	Name      string `xml:"name,attr"`
+	Manager   string `xml:"manager"`
+	Community string `xml:"community"`
+	User      string `xml:"user"`
+	EngineID  string `xml:"engineid"`
+}
+
+// redact hides the secret.
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "<REDACTED>"
+}
+
+// hardening is a row of the hardening sheet.
+type hardening struct {
+	category, item, value, result, note string
+}
+
+// yesOK returns the result of a setting that should be yes, such as
+// disable-http or enabled of password-complexity.
+func yesOK(value string) string {
+	if value == "yes" {
+		return "OK"
+	}
+	return "警告"
+}
+
+// hardenings returns the hardening items of the management plane.
+func hardenings(config *Config, vsys1 *Vsys) []hardening {
+	system := config.DeviceConfigSystem
+	setting := config.DeviceConfigSetting
+	var h []hardening
+	h = append(h,
+		hardening{"サービス", "HTTP 無効", system.DisableHTTP,
+			yesOK(system.DisableHTTP), "平文の管理アクセスは無効にする"},
+		hardening{"サービス", "Telnet 無効", system.DisableTelnet,
+			yesOK(system.DisableTelnet), "平文の管理アクセスは無効にする"},
+		hardening{"サービス", "HTTPS 無効", system.DisableHTTPS, "-", ""},
+		hardening{"サービス", "SSH 無効", system.DisableSSH, "-", ""},
+		hardening{"サービス", "HTTP OCSP 無効", system.DisableHTTPOCSP, "-", ""},
+		hardening{"サービス", "User-ID サービス無効",
+			system.DisableUserIDService, "-", ""},
+		hardening{"サービス", "User-ID Syslog (SSL) 無効",
+			system.DisableUserIDSyslogSSL, "-", ""},
+		hardening{"サービス", "User-ID Syslog (UDP) 無効",
+			system.DisableUserIDSyslogUDP, "-", ""},
+	)
+	snmp := system.SNMPSetting
+	switch {
+	case system.DisableSNMP == "yes":
+		h = append(h, hardening{"SNMP", "SNMP 無効", system.DisableSNMP, "OK", ""})
+	case snmp.version() == "v2c":
+		h = append(h, hardening{"SNMP", "SNMP バージョン", "v2c", "警告",
+			"コミュニティ文字列が平文で送信される"})
+	case snmp.version() == "":
+		h = append(h, hardening{"SNMP", "SNMP バージョン", "未設定", "警告",
+			"SNMP が有効だがバージョンとコミュニティが設定されていない"})
+	default:
+		h = append(h, hardening{"SNMP", "SNMP バージョン", snmp.version(), "OK", ""})
+	}
+	var permittedIP []string
+	for _, e := range system.PermittedIP {
+		permittedIP = append(permittedIP, e.Name)
+	}
+	permitted := hardening{"アクセス制限", "許可IPアドレス",
+		strings.Join(permittedIP, " "), "OK", ""}
+	if len(system.PermittedIP) == 0 {
+		permitted.result = "警告"
+		permitted.note = "管理アクセスの送信元が制限されていない"
+	}
+	idle := hardening{"管理設定", "アイドルタイムアウト (分)",
+		setting.IdleTimeout, "OK", "未設定時は 60 分"}
+	if setting.IdleTimeout == "0" {
+		idle.result = "警告"
+		idle.note = "管理セッションがタイムアウトしない"
+	}
+	h = append(h, permitted, idle)
+	lockout := "OK"
+	if setting.FailedAttempts == "" || setting.FailedAttempts == "0" {
+		lockout = "警告"
+	}
+	h = append(h,
+		hardening{"管理設定", "ログイン失敗回数", setting.FailedAttempts, lockout,
+			"ロックアウトまでの失敗回数"},
+		hardening{"管理設定", "ロックアウト時間 (分)", setting.LockoutTime, "-", ""},
+		hardening{"管理設定", "APIキー有効期間 (分)", setting.APIKeyLifetime, "-", ""},
+		hardening{"パスワード", "パスワード複雑性", config.PasswordComplexity.Enabled,
+			yesOK(config.PasswordComplexity.Enabled), ""},
+		hardening{"パスワード", "最小文字数",
+			config.PasswordComplexity.MinimumLength, "-", ""},
+		hardening{"パスワード", "有効期限 (日)",
+			config.PasswordComplexity.ExpirationPeriod, "-", ""},
+	)
+	ntp := "OK"
+	if system.PrimaryNTPServer == "" {
+		ntp = "警告"
+	}
+	h = append(h, hardening{"時刻同期", "NTPサーバー",
+		strings.TrimSpace(system.PrimaryNTPServer + " " + system.SecondaryNTPServer),
+		ntp, ""})
+	syslog := "警告"
+	if len(config.Shared.SyslogProfile) > 0 || len(vsys1.SyslogProfile) > 0 {
+		syslog = "OK"
+	}
+	h = append(h, hardening{"ログ", "Syslogサーバープロファイル",
+		fmt.Sprint(len(config.Shared.SyslogProfile) + len(vsys1.SyslogProfile)),
+		syslog, ""})
+	return h
+}
+
+// outputHardening() is <deviceconfig><system><service> output process.
+func outputHardening(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := config.sheetName("管理プレーン")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputHardening: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"カテゴリ", 12}, {"項目", 30}, {"設定値", 30}, {"判定", 6},
+		{"備考", 40},
+	}); err != nil {
+		return fmt.Errorf("outputHardening: %w", err)
+	}
+	for i, e := range hardenings(config, vsys1) {
+		err := xl.SetRow(&[]any{i + 1, e.category, e.item, e.value, e.result,
+			e.note})
+		if err != nil {
+			return fmt.Errorf("outputHardening: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputHardening: %w", err)
+	}
+	return nil
+}
+
+// outputSNMP() is <snmp-setting> output process.
+func outputSNMP(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("SNMP")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSNMP: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"バージョン", 8}, {"タイプ", 12}, {"名前", 20}, {"ビュー", 16},
+		{"OID", 20}, {"オプション", 8}, {"マスク", 8}, {"認証", 12},
+		{"暗号化", 12},
+	}); err != nil {
+		return fmt.Errorf("outputSNMP: %w", err)
+	}
+	snmp := config.DeviceConfigSystem.SNMPSetting
+	r := 0
+	if snmp.V2cCommunity != nil {
+		r++
+		err := xl.SetRow(&[]any{r, "v2c", "コミュニティ",
+			redact(*snmp.V2cCommunity)})
+		if err != nil {
+			return fmt.Errorf("outputSNMP: %w", err)
+		}
+	}
+	if snmp.V3 != nil {
+		for _, e := range snmp.V3.Users {
+			r++
+			err := xl.SetRow(&[]any{r, "v3", "ユーザー", e.Name, e.View, "", "",
+				"", e.AuthProto + " " + redact(e.AuthPwd),
+				e.PrivProto + " " + redact(e.PrivPwd)})
+			if err != nil {
+				return fmt.Errorf("outputSNMP: %w", err)
+			}
+		}
+		for _, e := range snmp.V3.Views {
+			for _, v := range e.View {
+				r++
+				err := xl.SetRow(&[]any{r, "v3", "ビュー", v.Name, e.Name, v.OID,
+					v.Option, v.Mask})
+				if err != nil {
+					return fmt.Errorf("outputSNMP: %w", err)
+				}
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSNMP: %w", err)
+	}
+	return nil
+}
+
+// outputSNMPTrapProfile() is <log-settings><snmptrap> output process.
+func outputSNMPTrapProfile(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("SNMPトラップサーバー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSNMPTrapProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"プロファイル", 20}, {"バージョン", 8},
+		{"名前", 20}, {"マネージャー", 20}, {"コミュニティ/ユーザー", 16},
+		{"EngineID", 20},
+	}); err != nil {
+		return fmt.Errorf("outputSNMPTrapProfile: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []SNMPTrapProfile
+	}{
+		{"shared", config.Shared.SNMPTrapProfile},
+		{vsys1.Name, vsys1.SNMPTrapProfile},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			for _, s := range e.V2c {
+				r++
+				err := xl.SetRow(&[]any{r, location.name, e.Name, "v2c", s.Name,
+					s.Manager, redact(s.Community)})
+				if err != nil {
+					return fmt.Errorf("outputSNMPTrapProfile: %w", err)
+				}
+			}
+			for _, s := range e.V3 {
+				r++
+				err := xl.SetRow(&[]any{r, location.name, e.Name, "v3", s.Name,
+					s.Manager, s.User, s.EngineID})
+				if err != nil {
+					return fmt.Errorf("outputSNMPTrapProfile: %w", err)
+				}
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSNMPTrapProfile: %w", err)
+	}
+	return nil
+}
+
+// outputSyslogProfile() is <log-settings><syslog> output process.
+func outputSyslogProfile(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("Syslogサーバー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSyslogProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"プロファイル", 20}, {"名前", 20},
+		{"サーバー", 20}, {"トランスポート", 8}, {"ポート", 6},
+		{"フォーマット", 8}, {"ファシリティ", 10},
+	}); err != nil {
+		return fmt.Errorf("outputSyslogProfile: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []SyslogProfile
+	}{
+		{"shared", config.Shared.SyslogProfile},
+		{vsys1.Name, vsys1.SyslogProfile},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			for _, s := range e.Server {
+				r++
+				err := xl.SetRow(&[]any{r, location.name, e.Name, s.Name,
+					s.Server, s.Transport, s.Port, s.Format, s.Facility})
+				if err != nil {
+					return fmt.Errorf("outputSyslogProfile: %w", err)
+				}
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSyslogProfile: %w", err)
+	}
+	return nil
+}
+
+// outputSSHServerProfile() is <ssh><profiles> output process.
+func outputSSHServerProfile(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("SSHサーバープロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSSHServerProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"暗号", 40}, {"鍵交換", 40}, {"MAC", 40},
+	}); err != nil {
+		return fmt.Errorf("outputSSHServerProfile: %w", err)
+	}
+	entries := config.DeviceConfigSystem.SSHServerProfile
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{i + 1, e.Name, []string(e.Ciphers),
+			[]string(e.KEX), []string(e.MAC)})
+		if err != nil {
+			return fmt.Errorf("outputSSHServerProfile: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSSHServerProfile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package paloalto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// Nat is rulebase>nat>rules>entry
+type Nat struct {
+	Name                     string               `xml:"name,attr"`
+	From                     []string             `xml:"from>member"`
+	To                       []string             `xml:"to>member"`
+	ToInterface              string               `xml:"to-interface"`
+	Source                   []string             `xml:"source>member"`
+	Destination              []string             `xml:"destination>member"`
+	Service                  string               `xml:"service"`
+	SourceTranslation        NatSourceTranslation `xml:"source-translation"`
+	TranslatedAddress        string               `xml:"destination-translation>translated-address"`
+	TranslatedPort           string               `xml:"destination-translation>translated-port"`
+	DynamicTranslatedAddress string               `xml:"dynamic-destination-translation>translated-address"`
+	DynamicTranslatedPort    string               `xml:"dynamic-destination-translation>translated-port"`
+	Disabled                 string               `xml:"disabled"`
+	Description              string               `xml:"description"`
+}
+
+// NatSourceTranslation is source-translation
+type NatSourceTranslation struct {
+	DynamicIPAndPort *struct {
+		TranslatedAddress []string `xml:"translated-address>member"`
+		Interface         string   `xml:"interface-address>interface"`
+		IP                string   `xml:"interface-address>ip"`
+	} `xml:"dynamic-ip-and-port"`
+	DynamicIP *struct {
+		TranslatedAddress []string `xml:"translated-address>member"`
+	} `xml:"dynamic-ip"`
+	StaticIP *struct {
+		TranslatedAddress string `xml:"translated-address"`
+		BiDirectional     string `xml:"bi-directional"`
+	} `xml:"static-ip"`
+}
+
+// translation returns the type and the translated address.
+func (n NatSourceTranslation) translation() (string, string) {
+	switch {
+	case n.DynamicIPAndPort != nil:
+		if n.DynamicIPAndPort.Interface != "" {
+			address := n.DynamicIPAndPort.Interface
+			if n.DynamicIPAndPort.IP != "" {
+				address += " " + n.DynamicIPAndPort.IP
+			}
+			return "dynamic-ip-and-port", address
+		}
+		return "dynamic-ip-and-port",
+			strings.Join(n.DynamicIPAndPort.TranslatedAddress, "\n")
+	case n.DynamicIP != nil:
+		return "dynamic-ip", strings.Join(n.DynamicIP.TranslatedAddress, "\n")
+	case n.StaticIP != nil:
+		if n.StaticIP.BiDirectional == "yes" {
+			return "static-ip (bi-directional)", n.StaticIP.TranslatedAddress
+		}
+		return "static-ip", n.StaticIP.TranslatedAddress
+	}
+	return "", ""
+}
+
+// outputNat() is <nat> output process.
+func outputNat(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("NAT")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputNat: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"送信元ゾーン", 10}, {"宛先ゾーン", 10},
+		{"宛先インターフェイス", 14}, {"送信元", 30}, {"宛先", 30},
+		{"サービス", 16}, {"送信元変換", 16}, {"変換後送信元", 20},
+		{"変換後宛先", 20}, {"変換後ポート", 8}, {"無効", 6}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputNat: %w", err)
+	}
+	entries := vsys1.Nat
+	for i, e := range entries {
+		typ, source := e.SourceTranslation.translation()
+		address, port := e.TranslatedAddress, e.TranslatedPort
+		if e.DynamicTranslatedAddress != "" {
+			address, port = e.DynamicTranslatedAddress, e.DynamicTranslatedPort
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.From, e.To, e.ToInterface, e.Source, e.Destination,
+			e.Service, typ, source, address, port, e.Disabled, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputNat: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputNat: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package paloalto
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/charmbracelet/log"
+	"github.com/nonsugar-go/tomato-conv/internal/sheetname"
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// DeviceGroup is devices>entry>device-group>entry
+type DeviceGroup struct {
+	Vsys
+	Description  string           `xml:"description"`
+	Devices      []PanoramaDevice `xml:"devices>entry"`
+	PreSecurity  []Security       `xml:"pre-rulebase>security>rules>entry"`
+	PostSecurity []Security       `xml:"post-rulebase>security>rules>entry"`
+	PreNat       []Nat            `xml:"pre-rulebase>nat>rules>entry"`
+	PostNat      []Nat            `xml:"post-rulebase>nat>rules>entry"`
+}
+
+// DeviceGroupParent is readonly>devices>entry>device-group>entry
+type DeviceGroupParent struct {
+	Name     string `xml:"name,attr"`
+	ParentDG string `xml:"parent-dg"`
+}
+
+// PanoramaDevice is devices>entry
+type PanoramaDevice struct {
+	Name string `xml:"name,attr"`
+}
+
+func (p PanoramaDevice) String() string {
+	return p.Name
+}
+
+// Template is devices>entry>template>entry
+type Template struct {
+	Name        string           `xml:"name,attr"`
+	Description string           `xml:"description"`
+	Devices     []PanoramaDevice `xml:"devices>entry"`
+	DefaultVsys string           `xml:"settings>default-vsys"`
+	Config      Config           `xml:"config"`
+}
+
+// TemplateStack is devices>entry>template-stack>entry
+type TemplateStack struct {
+	Name        string           `xml:"name,attr"`
+	Description string           `xml:"description"`
+	Templates   []string         `xml:"templates>member"`
+	Devices     []PanoramaDevice `xml:"devices>entry"`
+}
+
+// isPanorama reports whether the config is a Panorama config.
+func (c *Config) isPanorama() bool {
+	return len(c.DeviceGroup) > 0 || len(c.Template) > 0 ||
+		len(c.TemplateStack) > 0
+}
+
+// parentDeviceGroup returns the parent of the device group.
+func (c *Config) parentDeviceGroup(name string) string {
+	for _, e := range c.DeviceGroupParent {
+		if e.Name == name && e.ParentDG != "" {
+			return e.ParentDG
+		}
+	}
+	return "shared"
+}
+
+// outputDeviceGroup() is <device-group> output process.
+func outputDeviceGroup(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("デバイスグループ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputDeviceGroup: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"親デバイスグループ", 20}, {"デバイス", 30},
+		{"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputDeviceGroup: %w", err)
+	}
+	entries := config.DeviceGroup
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, config.parentDeviceGroup(e.Name), e.Devices,
+			e.Description})
+		if err != nil {
+			return fmt.Errorf("outputDeviceGroup: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputDeviceGroup: %w", err)
+	}
+	return nil
+}
+
+// outputTemplate() is <template> and <template-stack> output process.
+func outputTemplate(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("テンプレート")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputTemplate: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"タイプ", 16}, {"名前", 20}, {"テンプレート", 30},
+		{"デバイス", 30}, {"デフォルトVSYS", 10}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputTemplate: %w", err)
+	}
+	templates := config.Template
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	r := 0
+	for _, e := range templates {
+		r++
+		err := xl.SetRow(&[]any{r, "テンプレート", e.Name, nil, e.Devices,
+			e.DefaultVsys, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputTemplate: %w", err)
+		}
+	}
+	stacks := config.TemplateStack
+	sort.SliceStable(stacks, func(i, j int) bool {
+		return stacks[i].Name < stacks[j].Name
+	})
+	for _, e := range stacks {
+		r++
+		err := xl.SetRow(&[]any{r, "テンプレートスタック", e.Name, e.Templates,
+			e.Devices, nil, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputTemplate: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputTemplate: %w", err)
+	}
+	return nil
+}
+
+// writeDeviceGroup outputs the objects and the pre/post rulebases of the
+// device group. Sheets without entries are omitted.
+func writeDeviceGroup(xl *excel.Excel, config *Config, dg *DeviceGroup) error {
+	vsys := &dg.Vsys
+	applicationGroup := func(xl *excel.Excel, vsys *Vsys) error {
+		return outputApplicationGroup(xl, config, vsys)
+	}
+	for _, o := range []struct {
+		n      int
+		output func(*excel.Excel, *Vsys) error
+	}{
+		{len(vsys.Tag), outputTag},
+		{len(vsys.Address), outputAddress},
+		{len(vsys.AddressGroup), outputAddressGroup},
+		{len(vsys.ExternalList), outputExternalList},
+		{len(vsys.Schedule), outputSchedule},
+		{len(vsys.Region), outputRegion},
+		{len(vsys.CustomURLCategory), outputCustomURLCategory},
+		{len(vsys.Application), outputApplication},
+		{len(vsys.ApplicationFilter), outputApplicationFilter},
+		{len(vsys.ApplicationGroup), applicationGroup},
+		{len(vsys.Service), outputService},
+		{len(vsys.ServiceGroup), outputServiceGroup},
+		{len(vsys.DynamicUserGroup), outputDynamicUserGroup},
+		{len(vsys.HIPObject), outputHIPObject},
+		{len(vsys.HIPProfile), outputHIPProfile},
+	} {
+		if o.n == 0 {
+			continue
+		}
+		if err := o.output(xl, vsys); err != nil {
+			return fmt.Errorf("writeDeviceGroup: %w", err)
+		}
+	}
+	for _, rulebase := range []struct {
+		name     string
+		security []Security
+		nat      []Nat
+	}{
+		{"pre", dg.PreSecurity, dg.PreNat},
+		{"post", dg.PostSecurity, dg.PostNat},
+	} {
+		v := *vsys
+		v.prefix = vsys.prefix + rulebase.name + " "
+		v.Security = rulebase.security
+		v.Nat = rulebase.nat
+		if len(v.Security) > 0 {
+			if err := outputSecurity(xl, config, &v); err != nil {
+				return fmt.Errorf("writeDeviceGroup: %w", err)
+			}
+		}
+		if len(v.Nat) > 0 {
+			if err := outputNat(xl, &v); err != nil {
+				return fmt.Errorf("writeDeviceGroup: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// hasDeviceConfig reports whether the config has any <deviceconfig> or
+// <mgt-config> settings. A template often leaves them all empty.
+func (c *Config) hasDeviceConfig() bool {
+	return !reflect.ValueOf(c.DeviceConfigSystem).IsZero() ||
+		c.DeviceConfigSetting != (DeviceConfigSetting{}) ||
+		c.PasswordComplexity != (PasswordComplexity{})
+}
+
+// writeTemplate outputs the network and device settings of the template.
+// Sheets without entries are omitted.
+func writeTemplate(xl *excel.Excel, t *Template, sheets *sheetname.Set) error {
+	config := &t.Config
+	config.prefix = t.Name + " "
+	config.sheets = sheets
+	vsys1 := &Vsys{}
+	if len(config.Vsys) > 0 {
+		vsys1 = &config.Vsys[0]
+	}
+	hardening := func(xl *excel.Excel, config *Config) error {
+		return outputHardening(xl, config, vsys1)
+	}
+	for _, o := range []struct {
+		ok     bool
+		output func(*excel.Excel, *Config) error
+	}{
+		{config.hasDeviceConfig(), hardening},
+		{config.DeviceConfigSetting != (DeviceConfigSetting{}), outputDeviceSetting},
+		{config.DeviceConfigSystem.SNMPSetting.version() != "", outputSNMP},
+		{len(config.DeviceConfigSystem.SSHServerProfile) > 0, outputSSHServerProfile},
+	} {
+		if !o.ok {
+			continue
+		}
+		if err := o.output(xl, config); err != nil {
+			return fmt.Errorf("writeTemplate: %w", err)
+		}
+	}
+	for _, o := range []struct {
+		n      int
+		output func(*excel.Excel, *Config) error
+	}{
+		{len(config.Ethernet), outputEthernet},
+		{len(config.AggregateEthernet), outputAggregateEthernet},
+		{len(config.LLDPProfile), outputLLDPProfile},
+		{len(config.VirtualWire), outputVirtualWire},
+		{len(config.VLAN), outputVLAN},
+		{len(config.VirtualRouter), outputVirtualRouterInterface},
+		{len(config.VirtualRouter), outputVirtualRouterStaticRoute},
+		{len(config.QosProfile), outputQosProfile},
+		{len(config.QosInterface), outputQosInterface},
+	} {
+		if o.n == 0 {
+			continue
+		}
+		if err := o.output(xl, config); err != nil {
+			return fmt.Errorf("writeTemplate: %w", err)
+		}
+	}
+	for i := range config.Vsys {
+		vsys := &config.Vsys[i]
+		if len(vsys.Zone) == 0 {
+			continue
+		}
+		vsys.prefix = config.prefix + vsys.Name + " "
+		vsys.sheets = config.sheets
+		if err := outputZone(xl, vsys); err != nil {
+			return fmt.Errorf("writeTemplate: %w", err)
+		}
+	}
+	return nil
+}
+
+// writePanoramaExcel outputs parameter sheets of a Panorama config to Excel.
+func writePanoramaExcel(outFile string, config *Config) error {
+	xl, err := excel.New(outFile)
+	if err != nil {
+		return fmt.Errorf("writePanoramaExcel: %w", err)
+	}
+	defer func() {
+		if err := xl.Close(); err != nil {
+			log.Errorf("writePanoramaExcel: %v", err)
+		}
+	}()
+	config.sheets = &sheetname.Set{}
+
+	// <users>
+	if err := outputUsers(xl, config); err != nil {
+		return fmt.Errorf("writePanoramaExcel: %w", err)
+	}
+
+	// <device-group>
+	if err := outputDeviceGroup(xl, config); err != nil {
+		return fmt.Errorf("writePanoramaExcel: %w", err)
+	}
+
+	// <template>, <template-stack>
+	if err := outputTemplate(xl, config); err != nil {
+		return fmt.Errorf("writePanoramaExcel: %w", err)
+	}
+
+	// <shared>
+	config.Shared.prefix = "shared "
+	config.Shared.sheets = config.sheets
+	if err := writeDeviceGroup(xl, config, &config.Shared); err != nil {
+		return fmt.Errorf("writePanoramaExcel: %w", err)
+	}
+
+	// <device-group><entry>
+	for i := range config.DeviceGroup {
+		dg := &config.DeviceGroup[i]
+		dg.prefix = dg.Name + " "
+		dg.sheets = config.sheets
+		if err := writeDeviceGroup(xl, config, dg); err != nil {
+			return fmt.Errorf("writePanoramaExcel: %w", err)
+		}
+	}
+
+	// <template><entry>
+	for i := range config.Template {
+		if err := writeTemplate(xl, &config.Template[i], config.sheets); err != nil {
+			return fmt.Errorf("writePanoramaExcel: %w", err)
+		}
+	}
+
+	if err := xl.SaveAndClose(); err != nil {
+		return fmt.Errorf("writePanoramaExcel: %w", err)
+	}
+	return nil
+}
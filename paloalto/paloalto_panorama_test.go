@@ -0,0 +1,71 @@
+package paloalto
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"github.com/nonsugar-go/tomato-conv/internal/sheetname"
+	"github.com/nonsugar-go/tools/excel"
+)
+
+func TestWriteTemplateDeviceConfig(t *testing.T) {
+	data := `<config><devices><entry name="localhost.localdomain"><template>
+<entry name="tmpl-device">
+  <config><devices><entry name="localhost.localdomain">
+    <deviceconfig>
+      <system>
+        <hostname>PA-01</hostname>
+        <snmp-setting><access-setting><version><v3>
+          <users><entry name="snmpuser"><view>all</view></entry></users>
+        </v3></version></access-setting></snmp-setting>
+        <ssh><profiles><mgmt-profiles><server-profiles>
+          <entry name="ssh-hardening"><ciphers><aes256-ctr/></ciphers></entry>
+        </server-profiles></mgmt-profiles></profiles></ssh>
+      </system>
+      <setting><management><idle-timeout>10</idle-timeout></management></setting>
+    </deviceconfig>
+  </entry></devices></config>
+</entry>
+<entry name="tmpl-network">
+  <config><devices><entry name="localhost.localdomain">
+    <network><interface><ethernet>
+      <entry name="ethernet1/1"><layer3/></entry>
+    </ethernet></interface></network>
+  </entry></devices></config>
+</entry>
+</template></entry></devices></config>`
+	var config Config
+	if err := xml.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatal(err)
+	}
+	xl, err := excel.New(filepath.Join(t.TempDir(), "out.xlsx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer xl.Close()
+	sheets := &sheetname.Set{}
+	for i := range config.Template {
+		if err := writeTemplate(xl, &config.Template[i], sheets); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tt := range []struct {
+		sheet string
+		want  bool
+	}{
+		{"tmpl-device 管理プレーン", true},
+		{"tmpl-device デバイス設定", true},
+		{"tmpl-device SNMP", true},
+		{"tmpl-device SSHサーバープロファイル", true},
+		{"tmpl-network イーサネット", true},
+		{"tmpl-network 管理プレーン", false},
+		{"tmpl-network デバイス設定", false},
+		{"tmpl-network SNMP", false},
+		{"tmpl-network SSHサーバープロファイル", false},
+	} {
+		if got := sheets.Has(tt.sheet); got != tt.want {
+			t.Errorf("sheet %q: got %v, want %v", tt.sheet, got, tt.want)
+		}
+	}
+}
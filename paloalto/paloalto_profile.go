@@ -0,0 +1,226 @@
+package paloalto
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// AggregateEthernet is devices>entry>network>interface>aggregate-ethernet>entry
+type AggregateEthernet struct {
+	Name                       string       `xml:"name,attr"`
+	IP                         []EthernetIP `xml:"layer3>ip>entry"`
+	InterfaceManagementProfile string       `xml:"layer3>interface-management-profile"`
+	NetflowProfile             string       `xml:"layer3>netflow-profile"`
+	LLDPEnable                 string       `xml:"layer3>lldp>enable"`
+	LLDPProfile                string       `xml:"layer3>lldp>profile"`
+	LACPEnable                 string       `xml:"layer3>lacp>enable"`
+	LACPMode                   string       `xml:"layer3>lacp>mode"`
+	TransmissionRate           string       `xml:"layer3>lacp>transmission-rate"`
+	FastFailover               string       `xml:"layer3>lacp>fast-failover"`
+	SystemPriority             string       `xml:"layer3>lacp>system-priority"`
+	MaxPorts                   string       `xml:"layer3>lacp>max-ports"`
+	Comment                    string       `xml:"comment"`
+}
+
+// LLDPProfile is devices>entry>network>profiles>lldp-profile>entry
+type LLDPProfile struct {
+	Name                   string `xml:"name,attr"`
+	Mode                   string `xml:"mode"`
+	SNMPSyslogNotification string `xml:"snmp-syslog-notification"`
+	PortDescription        string `xml:"option-tlvs>port-description"`
+	SystemName             string `xml:"option-tlvs>system-name"`
+	SystemDescription      string `xml:"option-tlvs>system-description"`
+	SystemCapabilities     string `xml:"option-tlvs>system-capabilities"`
+	ManagementAddress      string `xml:"option-tlvs>management-address>enabled"`
+}
+
+// NetflowProfile is server-profile>netflow>entry
+type NetflowProfile struct {
+	Name                   string          `xml:"name,attr"`
+	Server                 []NetflowServer `xml:"server>entry"`
+	TemplateRefreshMinutes string          `xml:"template-refresh-rate>minutes"`
+	TemplateRefreshPackets string          `xml:"template-refresh-rate>packets"`
+	ActiveTimeout          string          `xml:"active-timeout"`
+	ExportEnterpriseFields string          `xml:"export-enterprise-fields"`
+}
+
+// NetflowServer is server>entry
+type NetflowServer struct {
+	Name string `xml:"name,attr"`
+	Host string `xml:"host"`
+	Port string `xml:"port"`
+}
+
+func (n NetflowServer) String() string {
+	if n.Port == "" {
+		return n.Host
+	}
+	return n.Host + ":" + n.Port
+}
+
+// aggregateMembers returns the member interfaces of the aggregate group.
+func aggregateMembers(config *Config, name string) []string {
+	var members []string
+	for _, e := range config.Ethernet {
+		if e.AggregateGroup == name {
+			members = append(members, e.Name)
+		}
+	}
+	return members
+}
+
+// notFound marks the profile name that is not in the profile sheet.
+func notFound(name string, found bool) string {
+	if name == "" || found {
+		return name
+	}
+	return name + " (未定義)"
+}
+
+// markLLDPProfile marks the LLDP profile that is not in the LLDP profile sheet.
+func markLLDPProfile(config *Config, name string) string {
+	return notFound(name, slices.ContainsFunc(config.LLDPProfile,
+		func(e LLDPProfile) bool { return e.Name == name }))
+}
+
+// markNetflowProfile marks the NetFlow profile that is not in the NetFlow
+// profile sheet of shared or any vsys.
+func markNetflowProfile(config *Config, name string) string {
+	found := func(profiles []NetflowProfile) bool {
+		return slices.ContainsFunc(profiles,
+			func(e NetflowProfile) bool { return e.Name == name })
+	}
+	ok := found(config.Shared.NetflowProfile)
+	for _, vsys := range config.Vsys {
+		ok = ok || found(vsys.NetflowProfile)
+	}
+	return notFound(name, ok)
+}
+
+// markAggregateGroup adds the LACP mode of the aggregate group in the
+// aggregate ethernet sheet, or marks the group that is not in the sheet.
+func markAggregateGroup(config *Config, name string) string {
+	i := slices.IndexFunc(config.AggregateEthernet,
+		func(e AggregateEthernet) bool { return e.Name == name })
+	if name == "" || i < 0 {
+		return notFound(name, false)
+	}
+	if ae := config.AggregateEthernet[i]; ae.LACPEnable == "yes" {
+		mode := ae.LACPMode
+		if mode == "" {
+			mode = "passive"
+		}
+		return fmt.Sprintf("%s (LACP %s)", name, mode)
+	}
+	return name
+}
+
+// outputAggregateEthernet() is <aggregate-ethernet> output process.
+func outputAggregateEthernet(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("集約イーサネット")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputAggregateEthernet: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 10}, {"メンバー", 30}, {"IPアドレス", 18},
+		{"LACP", 6}, {"モード", 8}, {"送信レート", 8}, {"高速フェイルオーバー", 8},
+		{"システム優先度", 8}, {"最大ポート数", 6}, {"管理プロファイル", 10},
+		{"Netflowプロファイル", 10}, {"LLDP", 6}, {"LLDPプロファイル", 10},
+		{"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputAggregateEthernet: %w", err)
+	}
+	entries := config.AggregateEthernet
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{i + 1, e.Name, aggregateMembers(config, e.Name),
+			e.IP, e.LACPEnable, e.LACPMode, e.TransmissionRate, e.FastFailover,
+			e.SystemPriority, e.MaxPorts, e.InterfaceManagementProfile,
+			markNetflowProfile(config, e.NetflowProfile), e.LLDPEnable,
+			markLLDPProfile(config, e.LLDPProfile), e.Comment})
+		if err != nil {
+			return fmt.Errorf("outputAggregateEthernet: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputAggregateEthernet: %w", err)
+	}
+	return nil
+}
+
+// outputLLDPProfile() is <lldp-profile> output process.
+func outputLLDPProfile(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("LLDPプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputLLDPProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"モード", 16}, {"SNMP/Syslog通知", 8},
+		{"ポートの説明", 8}, {"システム名", 8}, {"システムの説明", 8},
+		{"システム機能", 8}, {"管理アドレス", 8},
+	}); err != nil {
+		return fmt.Errorf("outputLLDPProfile: %w", err)
+	}
+	entries := config.LLDPProfile
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{i + 1, e.Name, e.Mode, e.SNMPSyslogNotification,
+			e.PortDescription, e.SystemName, e.SystemDescription,
+			e.SystemCapabilities, e.ManagementAddress})
+		if err != nil {
+			return fmt.Errorf("outputLLDPProfile: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputLLDPProfile: %w", err)
+	}
+	return nil
+}
+
+// outputNetflowProfile() is <server-profile><netflow> output process.
+func outputNetflowProfile(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("Netflowプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputNetflowProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"サーバー", 40},
+		{"テンプレート更新 (分)", 8}, {"テンプレート更新 (パケット)", 8},
+		{"アクティブタイムアウト (分)", 8}, {"PAN-OSフィールド", 8},
+	}); err != nil {
+		return fmt.Errorf("outputNetflowProfile: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []NetflowProfile
+	}{
+		{"shared", config.Shared.NetflowProfile},
+		{vsys1.Name, vsys1.NetflowProfile},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			err := xl.SetRow(&[]any{r, location.name, e.Name, e.Server,
+				e.TemplateRefreshMinutes, e.TemplateRefreshPackets,
+				e.ActiveTimeout, e.ExportEnterpriseFields})
+			if err != nil {
+				return fmt.Errorf("outputNetflowProfile: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputNetflowProfile: %w", err)
+	}
+	return nil
+}
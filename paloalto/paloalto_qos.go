@@ -0,0 +1,203 @@
+package paloalto
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// QosProfile is devices>entry>network>qos>profile>entry
+type QosProfile struct {
+	Name             string     `xml:"name,attr"`
+	EgressMax        string     `xml:"aggregate-bandwidth>egress-max"`
+	EgressGuaranteed string     `xml:"aggregate-bandwidth>egress-guaranteed"`
+	Class            []QosClass `xml:"class>entry"`
+	ClassMbps        []QosClass `xml:"class-bandwidth-type>mbps>class>entry"`
+	ClassPercentage  []QosClass `xml:"class-bandwidth-type>percentage>class>entry"`
+}
+
+// QosClass is class>entry
+type QosClass struct {
+	Name             string `xml:"name,attr"`
+	Priority         string `xml:"priority"`
+	EgressMax        string `xml:"class-bandwidth>egress-max"`
+	EgressGuaranteed string `xml:"class-bandwidth>egress-guaranteed"`
+}
+
+// classes returns the classes of the profile and the bandwidth unit.
+func (q QosProfile) classes() ([]QosClass, string) {
+	switch {
+	case q.ClassPercentage != nil:
+		return q.ClassPercentage, "%"
+	case q.ClassMbps != nil:
+		return q.ClassMbps, "Mbps"
+	}
+	return q.Class, "Mbps"
+}
+
+// QosInterface is devices>entry>network>qos>interface>entry
+type QosInterface struct {
+	Name                   string           `xml:"name,attr"`
+	Enabled                string           `xml:"enabled"`
+	EgressMax              string           `xml:"interface-bandwidth>egress-max"`
+	RegularProfile         string           `xml:"regular-traffic>default-group>qos-profile"`
+	TunnelProfile          string           `xml:"tunnel-traffic>default-group>per-tunnel-qos-profile"`
+	TunnelEgressMax        string           `xml:"tunnel-traffic>bandwidth>egress-max"`
+	TunnelEgressGuaranteed string           `xml:"tunnel-traffic>bandwidth>egress-guaranteed"`
+	TunnelGroups           []QosTunnelGroup `xml:"tunnel-traffic>groups>entry"`
+}
+
+// QosTunnelGroup is tunnel-traffic>groups>entry
+type QosTunnelGroup struct {
+	Name   string            `xml:"name,attr"`
+	Tunnel []QosTunnelMember `xml:"entry"`
+}
+
+// QosTunnelMember is groups>entry>entry
+type QosTunnelMember struct {
+	Name       string `xml:"name,attr"`
+	QosProfile string `xml:"qos-profile"`
+}
+
+func (q QosTunnelMember) String() string {
+	if q.QosProfile == "" {
+		return q.Name
+	}
+	return q.Name + ":" + q.QosProfile
+}
+
+// QoS is rulebase>qos>rules>entry
+type QoS struct {
+	Name        string   `xml:"name,attr"`
+	From        []string `xml:"from>member"`
+	To          []string `xml:"to>member"`
+	Source      []string `xml:"source>member"`
+	Destination []string `xml:"destination>member"`
+	SourceUser  []string `xml:"source-user>member"`
+	Application []string `xml:"application>member"`
+	Service     []string `xml:"service>member"`
+	Category    []string `xml:"category>member"`
+	Class       string   `xml:"action>class"`
+	Disabled    string   `xml:"disabled"`
+	Description string   `xml:"description"`
+}
+
+// outputQosProfile() is <qos><profile> output process.
+func outputQosProfile(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("QoSプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputQosProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"最大帯域", 10}, {"保証帯域", 10},
+		{"クラス", 10}, {"優先度", 10}, {"クラス最大帯域", 10},
+		{"クラス保証帯域", 10}, {"単位", 6},
+	}); err != nil {
+		return fmt.Errorf("outputQosProfile: %w", err)
+	}
+	entries := config.QosProfile
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	r := 0
+	for _, e := range entries {
+		classes, unit := e.classes()
+		if len(classes) == 0 {
+			r++
+			err := xl.SetRow(&[]any{r, e.Name, e.EgressMax, e.EgressGuaranteed})
+			if err != nil {
+				return fmt.Errorf("outputQosProfile: %w", err)
+			}
+			continue
+		}
+		for _, c := range classes {
+			r++
+			err := xl.SetRow(&[]any{r, e.Name, e.EgressMax, e.EgressGuaranteed,
+				c.Name, c.Priority, c.EgressMax, c.EgressGuaranteed, unit})
+			if err != nil {
+				return fmt.Errorf("outputQosProfile: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputQosProfile: %w", err)
+	}
+	return nil
+}
+
+// outputQosInterface() is <qos><interface> output process.
+func outputQosInterface(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("QoSインターフェイス")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputQosInterface: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"インターフェイス", 16}, {"有効", 6}, {"最大帯域", 10},
+		{"クリアテキストプロファイル", 16}, {"トンネルプロファイル", 16},
+		{"トンネル最大帯域", 10}, {"トンネル保証帯域", 10},
+		{"トンネルグループ", 16}, {"トンネル", 40},
+	}); err != nil {
+		return fmt.Errorf("outputQosInterface: %w", err)
+	}
+	entries := config.QosInterface
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	r := 0
+	for _, e := range entries {
+		if len(e.TunnelGroups) == 0 {
+			r++
+			err := xl.SetRow(&[]any{r, e.Name, e.Enabled, e.EgressMax,
+				e.RegularProfile, e.TunnelProfile, e.TunnelEgressMax,
+				e.TunnelEgressGuaranteed})
+			if err != nil {
+				return fmt.Errorf("outputQosInterface: %w", err)
+			}
+			continue
+		}
+		for _, g := range e.TunnelGroups {
+			r++
+			err := xl.SetRow(&[]any{r, e.Name, e.Enabled, e.EgressMax,
+				e.RegularProfile, e.TunnelProfile, e.TunnelEgressMax,
+				e.TunnelEgressGuaranteed, g.Name, g.Tunnel})
+			if err != nil {
+				return fmt.Errorf("outputQosInterface: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputQosInterface: %w", err)
+	}
+	return nil
+}
+
+// outputQoS() is <qos> rulebase output process.
+func outputQoS(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("QoSポリシー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputQoS: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"送信元ゾーン", 10},
+		{"宛先ゾーン", 10}, {"送信元", 30}, {"宛先", 30},
+		{"送信元ユーザー", 20}, {"アプリケーション", 30}, {"サービス", 30},
+		{"URLカテゴリ", 20}, {"クラス", 6}, {"無効", 6}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputQoS: %w", err)
+	}
+	entries := vsys1.QoS
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.From, e.To, e.Source, e.Destination, e.SourceUser,
+			e.Application, e.Service, e.Category, e.Class, e.Disabled,
+			e.Description})
+		if err != nil {
+			return fmt.Errorf("outputQoS: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputQoS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package paloalto
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// Schedule is schedule>entry
+type Schedule struct {
+	Name         string          `xml:"name,attr"`
+	Daily        []string        `xml:"schedule-type>recurring>daily>member"`
+	Weekly       *ScheduleWeekly `xml:"schedule-type>recurring>weekly"`
+	NonRecurring []string        `xml:"schedule-type>non-recurring>member"`
+}
+
+// ScheduleWeekly is recurring>weekly
+type ScheduleWeekly struct {
+	Sunday    []string `xml:"sunday>member"`
+	Monday    []string `xml:"monday>member"`
+	Tuesday   []string `xml:"tuesday>member"`
+	Wednesday []string `xml:"wednesday>member"`
+	Thursday  []string `xml:"thursday>member"`
+	Friday    []string `xml:"friday>member"`
+	Saturday  []string `xml:"saturday>member"`
+}
+
+// windows returns the time windows prefixed with the day of the week.
+func (s ScheduleWeekly) windows() []string {
+	var w []string
+	for _, day := range []struct {
+		name    string
+		windows []string
+	}{
+		{"sunday", s.Sunday},
+		{"monday", s.Monday},
+		{"tuesday", s.Tuesday},
+		{"wednesday", s.Wednesday},
+		{"thursday", s.Thursday},
+		{"friday", s.Friday},
+		{"saturday", s.Saturday},
+	} {
+		for _, window := range day.windows {
+			w = append(w, day.name+" "+window)
+		}
+	}
+	return w
+}
+
+// Region is region>entry
+type Region struct {
+	Name      string   `xml:"name,attr"`
+	Latitude  string   `xml:"geo-location>latitude"`
+	Longitude string   `xml:"geo-location>longitude"`
+	Address   []string `xml:"address>member"`
+}
+
+// CustomURLCategory is profiles>custom-url-category>entry
+type CustomURLCategory struct {
+	Name        string   `xml:"name,attr"`
+	Type        string   `xml:"type"`
+	List        []string `xml:"list>member"`
+	Description string   `xml:"description"`
+}
+
+// outputSchedule() is <schedule> output process.
+func outputSchedule(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("スケジュール")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSchedule: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 14}, {"時間帯", 60},
+	}); err != nil {
+		return fmt.Errorf("outputSchedule: %w", err)
+	}
+	entries := vsys1.Schedule
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		typ := ""
+		var windows []string
+		switch {
+		case e.Daily != nil:
+			typ = "daily"
+			windows = e.Daily
+		case e.Weekly != nil:
+			typ = "weekly"
+			windows = e.Weekly.windows()
+		case e.NonRecurring != nil:
+			typ = "non-recurring"
+			windows = e.NonRecurring
+		}
+		err := xl.SetRow(&[]any{i + 1, e.Name, typ, windows})
+		if err != nil {
+			return fmt.Errorf("outputSchedule: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSchedule: %w", err)
+	}
+	return nil
+}
+
+// outputRegion() is <region> output process.
+func outputRegion(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("リージョン")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputRegion: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"緯度", 12}, {"経度", 12}, {"アドレス", 60},
+	}); err != nil {
+		return fmt.Errorf("outputRegion: %w", err)
+	}
+	entries := vsys1.Region
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.Latitude, e.Longitude, e.Address})
+		if err != nil {
+			return fmt.Errorf("outputRegion: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputRegion: %w", err)
+	}
+	return nil
+}
+
+// outputCustomURLCategory() is <custom-url-category> output process.
+func outputCustomURLCategory(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("カスタムURLカテゴリ")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputCustomURLCategory: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 14}, {"URL/カテゴリ", 60},
+		{"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputCustomURLCategory: %w", err)
+	}
+	entries := vsys1.CustomURLCategory
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		typ := e.Type
+		if typ == "" {
+			typ = "URL List"
+		}
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, typ, e.List, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputCustomURLCategory: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputCustomURLCategory: %w", err)
+	}
+	return nil
+}
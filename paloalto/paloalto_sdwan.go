@@ -0,0 +1,269 @@
+package paloalto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// SDWANInterfaceProfile is devices>entry>network>profiles>sdwan-interface-profile>entry
+type SDWANInterfaceProfile struct {
+	Name                 string `xml:"name,attr"`
+	LinkTag              string `xml:"link-tag"`
+	LinkType             string `xml:"link-type"`
+	MaximumDownload      string `xml:"maximum-download"`
+	MaximumUpload        string `xml:"maximum-upload"`
+	VPNDataTunnelSupport string `xml:"vpn-data-tunnel-support"`
+	PathMonitoring       string `xml:"path-monitoring"`
+	ProbeFrequency       string `xml:"probe-frequency"`
+	FailbackHoldTime     string `xml:"failback-hold-time"`
+	Comment              string `xml:"comment"`
+}
+
+// SDWANPathQuality is profiles>sdwan-path-quality>entry
+type SDWANPathQuality struct {
+	Name           string `xml:"name,attr"`
+	Latency        string `xml:"metric>latency>threshold"`
+	LatencySens    string `xml:"metric>latency>sensitivity"`
+	Jitter         string `xml:"metric>jitter>threshold"`
+	JitterSens     string `xml:"metric>jitter>sensitivity"`
+	PacketLoss     string `xml:"metric>pkt-loss>threshold"`
+	PacketLossSens string `xml:"metric>pkt-loss>sensitivity"`
+}
+
+func (s SDWANPathQuality) String() string {
+	var metrics []string
+	if s.Latency != "" {
+		metrics = append(metrics, "latency "+s.Latency+"ms")
+	}
+	if s.Jitter != "" {
+		metrics = append(metrics, "jitter "+s.Jitter+"ms")
+	}
+	if s.PacketLoss != "" {
+		metrics = append(metrics, "pkt-loss "+s.PacketLoss+"%")
+	}
+	return strings.Join(metrics, ", ")
+}
+
+// SDWANTrafficDistribution is profiles>sdwan-traffic-distribution>entry
+type SDWANTrafficDistribution struct {
+	Name                string         `xml:"name,attr"`
+	TrafficDistribution string         `xml:"traffic-distribution"`
+	LinkTags            []SDWANLinkTag `xml:"link-tags>entry"`
+}
+
+// SDWANLinkTag is link-tags>entry
+type SDWANLinkTag struct {
+	Name string `xml:"name,attr"`
+	// Weight is used by the Weighted Session Distribution.
+	Weight string `xml:"weight"`
+}
+
+func (s SDWANLinkTag) String() string {
+	if s.Weight == "" {
+		return s.Name
+	}
+	return s.Name + " (" + s.Weight + "%)"
+}
+
+func (s SDWANTrafficDistribution) String() string {
+	var tags []string
+	for _, tag := range s.LinkTags {
+		tags = append(tags, tag.String())
+	}
+	return s.TrafficDistribution + ": " + strings.Join(tags, ", ")
+}
+
+// SDWAN is rulebase>sdwan>rules>entry
+type SDWAN struct {
+	Name                       string   `xml:"name,attr"`
+	From                       []string `xml:"from>member"`
+	To                         []string `xml:"to>member"`
+	Source                     []string `xml:"source>member"`
+	Destination                []string `xml:"destination>member"`
+	SourceUser                 []string `xml:"source-user>member"`
+	Application                []string `xml:"application>member"`
+	Service                    []string `xml:"service>member"`
+	PathQualityProfile         string   `xml:"path-quality-profile"`
+	TrafficDistributionProfile string   `xml:"action>traffic-distribution-profile"`
+	Disabled                   string   `xml:"disabled"`
+	Description                string   `xml:"description"`
+}
+
+// pathQuality returns the path quality profile.
+func pathQuality(config *Config, vsys1 *Vsys, name string) string {
+	for _, profiles := range [][]SDWANPathQuality{
+		vsys1.SDWANPathQuality, config.Shared.SDWANPathQuality,
+	} {
+		for _, e := range profiles {
+			if e.Name == name {
+				return e.String()
+			}
+		}
+	}
+	return ""
+}
+
+// trafficDistribution returns the traffic distribution profile.
+func trafficDistribution(config *Config, vsys1 *Vsys, name string) string {
+	for _, profiles := range [][]SDWANTrafficDistribution{
+		vsys1.SDWANTrafficDistribution, config.Shared.SDWANTrafficDistribution,
+	} {
+		for _, e := range profiles {
+			if e.Name == name {
+				return e.String()
+			}
+		}
+	}
+	return ""
+}
+
+// outputSDWANInterfaceProfile() is <sdwan-interface-profile> output process.
+func outputSDWANInterfaceProfile(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("SD-WANインターフェイスプロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSDWANInterfaceProfile: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"リンクタグ", 14}, {"リンクタイプ", 14},
+		{"最大ダウンロード (Mbps)", 10}, {"最大アップロード (Mbps)", 10},
+		{"VPNデータトンネル", 8}, {"パスモニタリング", 12},
+		{"プローブ頻度", 8}, {"フェイルバック保持時間", 8}, {"コメント", 40},
+	}); err != nil {
+		return fmt.Errorf("outputSDWANInterfaceProfile: %w", err)
+	}
+	entries := config.SDWANInterfaceProfile
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{i + 1, e.Name, e.LinkTag, e.LinkType,
+			e.MaximumDownload, e.MaximumUpload, e.VPNDataTunnelSupport,
+			e.PathMonitoring, e.ProbeFrequency, e.FailbackHoldTime, e.Comment})
+		if err != nil {
+			return fmt.Errorf("outputSDWANInterfaceProfile: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSDWANInterfaceProfile: %w", err)
+	}
+	return nil
+}
+
+// outputSDWANPathQuality() is <sdwan-path-quality> output process.
+func outputSDWANPathQuality(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("SD-WANパス品質プロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSDWANPathQuality: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"遅延 (ms)", 8}, {"遅延感度", 8},
+		{"ジッター (ms)", 8}, {"ジッター感度", 8}, {"パケット損失 (%)", 8},
+		{"パケット損失感度", 8},
+	}); err != nil {
+		return fmt.Errorf("outputSDWANPathQuality: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []SDWANPathQuality
+	}{
+		{"shared", config.Shared.SDWANPathQuality},
+		{vsys1.Name, vsys1.SDWANPathQuality},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			err := xl.SetRow(&[]any{r, location.name, e.Name, e.Latency,
+				e.LatencySens, e.Jitter, e.JitterSens, e.PacketLoss,
+				e.PacketLossSens})
+			if err != nil {
+				return fmt.Errorf("outputSDWANPathQuality: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSDWANPathQuality: %w", err)
+	}
+	return nil
+}
+
+// outputSDWANTrafficDistribution() is <sdwan-traffic-distribution> output
+// process.
+func outputSDWANTrafficDistribution(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("SD-WANトラフィック分散プロファイル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSDWANTrafficDistribution: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"場所", 8}, {"名前", 20}, {"分散方式", 30},
+		{"リンクタグ", 40},
+	}); err != nil {
+		return fmt.Errorf("outputSDWANTrafficDistribution: %w", err)
+	}
+	r := 0
+	for _, location := range []struct {
+		name    string
+		entries []SDWANTrafficDistribution
+	}{
+		{"shared", config.Shared.SDWANTrafficDistribution},
+		{vsys1.Name, vsys1.SDWANTrafficDistribution},
+	} {
+		entries := location.entries
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		for _, e := range entries {
+			r++
+			err := xl.SetRow(&[]any{r, location.name, e.Name,
+				e.TrafficDistribution, e.LinkTags})
+			if err != nil {
+				return fmt.Errorf("outputSDWANTrafficDistribution: %w", err)
+			}
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSDWANTrafficDistribution: %w", err)
+	}
+	return nil
+}
+
+// outputSDWAN() is <sdwan> rulebase output process.
+func outputSDWAN(xl *excel.Excel, config *Config, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("SD-WANポリシー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputSDWAN: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"送信元ゾーン", 10},
+		{"宛先ゾーン", 10}, {"送信元", 30}, {"宛先", 30},
+		{"送信元ユーザー", 20}, {"アプリケーション", 30}, {"サービス", 30},
+		{"パス品質プロファイル", 16}, {"パス品質", 40},
+		{"トラフィック分散プロファイル", 16}, {"トラフィック分散", 40},
+		{"無効", 6}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputSDWAN: %w", err)
+	}
+	entries := vsys1.SDWAN
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.From, e.To, e.Source, e.Destination, e.SourceUser,
+			e.Application, e.Service, e.PathQualityProfile,
+			pathQuality(config, vsys1, e.PathQualityProfile),
+			e.TrafficDistributionProfile,
+			trafficDistribution(config, vsys1, e.TrafficDistributionProfile),
+			e.Disabled, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputSDWAN: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputSDWAN: %w", err)
+	}
+	return nil
+}
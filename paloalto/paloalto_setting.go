@@ -0,0 +1,125 @@
+package paloalto
+
+import (
+	"fmt"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// DeviceConfigSetting is devices>entry>deviceconfig>setting
+type DeviceConfigSetting struct {
+	IdleTimeout    string `xml:"management>idle-timeout"`
+	FailedAttempts string `xml:"management>admin-lockout>failed-attempts"`
+	LockoutTime    string `xml:"management>admin-lockout>lockout-time"`
+	APIKeyLifetime string `xml:"management>api>key>lifetime"`
+
+	TimeoutDefault        string `xml:"session>timeout-default"`
+	TimeoutDiscardDefault string `xml:"session>timeout-discard-default"`
+	TimeoutDiscardTCP     string `xml:"session>timeout-discard-tcp"`
+	TimeoutDiscardUDP     string `xml:"session>timeout-discard-udp"`
+	TimeoutICMP           string `xml:"session>timeout-icmp"`
+	TimeoutScan           string `xml:"session>timeout-scan"`
+	TimeoutTCP            string `xml:"session>timeout-tcp"`
+	TimeoutTCPInit        string `xml:"session>timeout-tcpinit"`
+	TimeoutTCPHandshake   string `xml:"session>timeout-tcphandshake"`
+	TimeoutTCPHalfClosed  string `xml:"session>timeout-tcp-half-closed"`
+	TimeoutTCPTimeWait    string `xml:"session>timeout-tcp-time-wait"`
+	TimeoutUnverifiedRST  string `xml:"session>timeout-unverified-rst"`
+	TimeoutUDP            string `xml:"session>timeout-udp"`
+	TimeoutCaptivePortal  string `xml:"session>timeout-captive-portal"`
+	TCPRejectNonSYN       string `xml:"session>tcp-reject-non-syn"`
+	Offload               string `xml:"session>offload"`
+	IPv6Firewalling       string `xml:"session>ipv6-firewalling"`
+
+	AsymmetricPath       string `xml:"tcp>asymmetric-path"`
+	UrgentData           string `xml:"tcp>urgent-data"`
+	DropZeroFlag         string `xml:"tcp>drop-zero-flag"`
+	BypassExceedOOQueue  string `xml:"tcp>bypass-exceed-oo-queue"`
+	CheckTimestampOption string `xml:"tcp>check-timestamp-option"`
+	StripMPTCPOption     string `xml:"tcp>strip-mptcp-option"`
+
+	JumboFrameMTU string `xml:"jumbo-frame>mtu"`
+
+	TCPBypassExceedQueue   string `xml:"ctd>tcp-bypass-exceed-queue"`
+	UDPBypassExceedQueue   string `xml:"ctd>udp-bypass-exceed-queue"`
+	AllowHTTPRange         string `xml:"ctd>allow-http-range"`
+	ExtendedCaptureSegment string `xml:"ctd>extended-capture-segment"`
+
+	AppIDCache                string `xml:"application>cache"`
+	UseCacheForIdentification string `xml:"application>use-cache-for-identification"`
+	DumpUnknown               string `xml:"application>dump-unknown"`
+	Rematch                   string `xml:"config>rematch"`
+}
+
+// deviceSetting is a row of the device settings sheet.
+type deviceSetting struct {
+	category, name, value, defaultValue string
+}
+
+// deviceSettings returns the device settings with the PAN-OS defaults.
+func (s DeviceConfigSetting) deviceSettings() []deviceSetting {
+	return []deviceSetting{
+		{"セッション", "timeout-default", s.TimeoutDefault, "30"},
+		{"セッション", "timeout-discard-default", s.TimeoutDiscardDefault, "60"},
+		{"セッション", "timeout-discard-tcp", s.TimeoutDiscardTCP, "90"},
+		{"セッション", "timeout-discard-udp", s.TimeoutDiscardUDP, "60"},
+		{"セッション", "timeout-icmp", s.TimeoutICMP, "6"},
+		{"セッション", "timeout-scan", s.TimeoutScan, "10"},
+		{"セッション", "timeout-tcp", s.TimeoutTCP, "3600"},
+		{"セッション", "timeout-tcpinit", s.TimeoutTCPInit, "5"},
+		{"セッション", "timeout-tcphandshake", s.TimeoutTCPHandshake, "10"},
+		{"セッション", "timeout-tcp-half-closed", s.TimeoutTCPHalfClosed, "120"},
+		{"セッション", "timeout-tcp-time-wait", s.TimeoutTCPTimeWait, "15"},
+		{"セッション", "timeout-unverified-rst", s.TimeoutUnverifiedRST, "30"},
+		{"セッション", "timeout-udp", s.TimeoutUDP, "30"},
+		{"セッション", "timeout-captive-portal", s.TimeoutCaptivePortal, "30"},
+		{"セッション", "tcp-reject-non-syn", s.TCPRejectNonSYN, "yes"},
+		{"セッション", "offload", s.Offload, "yes"},
+		{"セッション", "ipv6-firewalling", s.IPv6Firewalling, "yes"},
+		{"TCP", "asymmetric-path", s.AsymmetricPath, "drop"},
+		{"TCP", "urgent-data", s.UrgentData, "clear"},
+		{"TCP", "drop-zero-flag", s.DropZeroFlag, "yes"},
+		{"TCP", "bypass-exceed-oo-queue", s.BypassExceedOOQueue, "no"},
+		{"TCP", "check-timestamp-option", s.CheckTimestampOption, "yes"},
+		{"TCP", "strip-mptcp-option", s.StripMPTCPOption, "yes"},
+		{"ジャンボフレーム", "mtu", s.JumboFrameMTU, "9192"},
+		{"コンテンツID", "tcp-bypass-exceed-queue", s.TCPBypassExceedQueue, "no"},
+		{"コンテンツID", "udp-bypass-exceed-queue", s.UDPBypassExceedQueue, "no"},
+		{"コンテンツID", "allow-http-range", s.AllowHTTPRange, "yes"},
+		{"コンテンツID", "extended-capture-segment", s.ExtendedCaptureSegment, "5"},
+		{"App-ID", "cache", s.AppIDCache, "yes"},
+		{"App-ID", "use-cache-for-identification",
+			s.UseCacheForIdentification, "yes"},
+		{"App-ID", "dump-unknown", s.DumpUnknown, "no"},
+		{"App-ID", "rematch", s.Rematch, "yes"},
+	}
+}
+
+// outputDeviceSetting() is <deviceconfig><setting> output process.
+func outputDeviceSetting(xl *excel.Excel, config *Config) error {
+	sheet := config.sheetName("デバイス設定")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputDeviceSetting: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"カテゴリ", 14}, {"設定", 30}, {"設定値", 12},
+		{"デフォルト", 12}, {"デフォルト以外", 8},
+	}); err != nil {
+		return fmt.Errorf("outputDeviceSetting: %w", err)
+	}
+	for i, e := range config.DeviceConfigSetting.deviceSettings() {
+		changed := ""
+		if e.value != "" && e.value != e.defaultValue {
+			changed = "変更"
+		}
+		err := xl.SetRow(&[]any{i + 1, e.category, e.name, e.value,
+			e.defaultValue, changed})
+		if err != nil {
+			return fmt.Errorf("outputDeviceSetting: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputDeviceSetting: %w", err)
+	}
+	return nil
+}
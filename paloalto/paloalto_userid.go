@@ -0,0 +1,271 @@
+package paloalto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nonsugar-go/tools/excel"
+)
+
+// UserIDAgent is user-id-agent>entry
+type UserIDAgent struct {
+	Name          string `xml:"name,attr"`
+	Host          string `xml:"host-port>host"`
+	Port          string `xml:"host-port>port"`
+	LDAPProxy     string `xml:"host-port>ldap-proxy"`
+	NTLMAuth      string `xml:"host-port>ntlm-auth"`
+	CollectorName string `xml:"host-port>collectorname"`
+	Serial        string `xml:"serial-number"`
+	Disabled      string `xml:"disabled"`
+}
+
+// UserIDServerMonitor is user-id-collector>server-monitor>entry
+type UserIDServerMonitor struct {
+	Name            string `xml:"name,attr"`
+	ActiveDirectory string `xml:"active-directory>host"`
+	Exchange        string `xml:"exchange>host"`
+	EDirectory      string `xml:"e-directory>host"`
+	Syslog          string `xml:"syslog>address"`
+	Enabled         string `xml:"enable"`
+}
+
+// typ returns the type and the host of the monitored server.
+func (u UserIDServerMonitor) typ() (string, string) {
+	switch {
+	case u.ActiveDirectory != "":
+		return "Active Directory", u.ActiveDirectory
+	case u.Exchange != "":
+		return "Exchange", u.Exchange
+	case u.EDirectory != "":
+		return "eDirectory", u.EDirectory
+	case u.Syslog != "":
+		return "Syslog", u.Syslog
+	}
+	return "", ""
+}
+
+// GroupMapping is group-mapping>entry
+type GroupMapping struct {
+	Name             string   `xml:"name,attr"`
+	ServerProfile    string   `xml:"server-profile"`
+	Domain           string   `xml:"domain"`
+	UpdateInterval   string   `xml:"update-interval"`
+	GroupFilter      string   `xml:"group-filter"`
+	UserFilter       string   `xml:"user-filter"`
+	GroupIncludeList []string `xml:"group-include-list>member"`
+	Disabled         string   `xml:"disabled"`
+}
+
+// CaptivePortal is captive-portal
+type CaptivePortal struct {
+	Enable                string    `xml:"enable-captive-portal"`
+	Transparent           *struct{} `xml:"mode>transparent"`
+	Redirect              *struct{} `xml:"mode>redirect"`
+	RedirectHost          string    `xml:"redirect-host"`
+	AuthenticationProfile string    `xml:"authentication-profile"`
+	CertificateProfile    string    `xml:"certificate-profile"`
+	SSLTLSServiceProfile  string    `xml:"ssl-tls-service-profile"`
+	IdleTimer             string    `xml:"idle-timer"`
+	Timer                 string    `xml:"timer"`
+	GPUDPPort             string    `xml:"gp-udp-port"`
+}
+
+// mode returns the mode of the captive portal.
+func (c CaptivePortal) mode() string {
+	switch {
+	case c.Transparent != nil:
+		return "transparent"
+	case c.Redirect != nil:
+		return "redirect"
+	}
+	return ""
+}
+
+// Authentication is rulebase>authentication>rules>entry
+type Authentication struct {
+	Name                      string   `xml:"name,attr"`
+	From                      []string `xml:"from>member"`
+	To                        []string `xml:"to>member"`
+	Source                    []string `xml:"source>member"`
+	Destination               []string `xml:"destination>member"`
+	SourceUser                []string `xml:"source-user>member"`
+	Service                   []string `xml:"service>member"`
+	Category                  []string `xml:"category>member"`
+	AuthenticationEnforcement string   `xml:"authentication-enforcement"`
+	Timeout                   string   `xml:"timeout"`
+	Disabled                  string   `xml:"disabled"`
+	Description               string   `xml:"description"`
+}
+
+// outputUserIDAgent() is <user-id-agent> output process.
+func outputUserIDAgent(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("User-IDエージェント")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputUserIDAgent: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"タイプ", 16}, {"ホスト", 20}, {"ポート", 6},
+		{"LDAPプロキシ", 8}, {"NTLM認証", 8}, {"コレクタ名", 16}, {"有効", 6},
+	}); err != nil {
+		return fmt.Errorf("outputUserIDAgent: %w", err)
+	}
+	entries := vsys1.UserIDAgent
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	r := 0
+	for _, e := range entries {
+		r++
+		enabled := "yes"
+		if e.Disabled == "yes" {
+			enabled = "no"
+		}
+		host := e.Host
+		if host == "" {
+			host = e.Serial
+		}
+		err := xl.SetRow(&[]any{r, e.Name, "User-ID Agent", host, e.Port,
+			e.LDAPProxy, e.NTLMAuth, e.CollectorName, enabled})
+		if err != nil {
+			return fmt.Errorf("outputUserIDAgent: %w", err)
+		}
+	}
+	monitors := vsys1.UserIDServerMonitor
+	sort.SliceStable(monitors, func(i, j int) bool {
+		return monitors[i].Name < monitors[j].Name
+	})
+	for _, e := range monitors {
+		r++
+		typ, host := e.typ()
+		err := xl.SetRow(&[]any{r, e.Name, typ, host, "", "", "", "",
+			e.Enabled})
+		if err != nil {
+			return fmt.Errorf("outputUserIDAgent: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputUserIDAgent: %w", err)
+	}
+	return nil
+}
+
+// outputGroupMapping() is <group-mapping> output process.
+func outputGroupMapping(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("グループマッピング")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputGroupMapping: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"サーバープロファイル", 16}, {"ドメイン", 16},
+		{"更新間隔", 8}, {"グループフィルタ", 20}, {"ユーザーフィルタ", 20},
+		{"グループ包含リスト", 60}, {"無効", 6},
+	}); err != nil {
+		return fmt.Errorf("outputGroupMapping: %w", err)
+	}
+	entries := vsys1.GroupMapping
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.ServerProfile, e.Domain, e.UpdateInterval,
+			e.GroupFilter, e.UserFilter, e.GroupIncludeList, e.Disabled})
+		if err != nil {
+			return fmt.Errorf("outputGroupMapping: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputGroupMapping: %w", err)
+	}
+	return nil
+}
+
+// outputCaptivePortal() is <captive-portal> output process.
+func outputCaptivePortal(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("認証ポータル")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputCaptivePortal: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"項目", 30}, {"値", 40},
+	}); err != nil {
+		return fmt.Errorf("outputCaptivePortal: %w", err)
+	}
+	portal := vsys1.CaptivePortal
+	if portal == nil {
+		portal = vsys1.AuthenticationPortal
+	}
+	if portal == nil {
+		portal = &CaptivePortal{}
+	}
+	for i, item := range []struct{ name, value string }{
+		{"有効", portal.Enable},
+		{"モード", portal.mode()},
+		{"リダイレクトホスト", portal.RedirectHost},
+		{"認証プロファイル", portal.AuthenticationProfile},
+		{"証明書プロファイル", portal.CertificateProfile},
+		{"SSL/TLSサービスプロファイル", portal.SSLTLSServiceProfile},
+		{"アイドルタイマー (分)", portal.IdleTimer},
+		{"タイマー (分)", portal.Timer},
+		{"GlobalProtectネットワークポート", portal.GPUDPPort},
+	} {
+		err := xl.SetRow(&[]any{i + 1, item.name, item.value})
+		if err != nil {
+			return fmt.Errorf("outputCaptivePortal: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputCaptivePortal: %w", err)
+	}
+	return nil
+}
+
+// outputAuthentication() is <authentication> rulebase output process.
+func outputAuthentication(xl *excel.Excel, vsys1 *Vsys) error {
+	sheet := vsys1.sheetName("認証ポリシー")
+	if err := xl.NewSheet(sheet); err != nil {
+		return fmt.Errorf("outputAuthentication: %w", err)
+	}
+	if err := xl.SetHeader([]excel.Header{
+		{"#", 4}, {"名前", 20}, {"送信元ゾーン", 10},
+		{"宛先ゾーン", 10}, {"送信元", 30}, {"宛先", 30},
+		{"送信元ユーザー", 20}, {"サービス", 30}, {"URLカテゴリ", 20},
+		{"認証強制", 16}, {"タイムアウト", 8}, {"無効", 6}, {"内容", 60},
+	}); err != nil {
+		return fmt.Errorf("outputAuthentication: %w", err)
+	}
+	entries := vsys1.Authentication
+	for i, e := range entries {
+		err := xl.SetRow(&[]any{
+			i + 1, e.Name, e.From, e.To, e.Source, e.Destination, e.SourceUser,
+			e.Service, e.Category, e.AuthenticationEnforcement, e.Timeout,
+			e.Disabled, e.Description})
+		if err != nil {
+			return fmt.Errorf("outputAuthentication: %w", err)
+		}
+	}
+	if err := xl.AddTable(sheet); err != nil {
+		return fmt.Errorf("outputAuthentication: %w", err)
+	}
+	return nil
+}
+
+// markGroupMapping marks the users and groups that are included in a group
+// mapping.
+func markGroupMapping(vsys1 *Vsys, members []string) []string {
+	marked := make([]string, 0, len(members))
+	for _, member := range members {
+	mapping:
+		for _, g := range vsys1.GroupMapping {
+			for _, group := range g.GroupIncludeList {
+				if strings.EqualFold(group, member) {
+					member += " (" + g.Name + ")"
+					break mapping
+				}
+			}
+		}
+		marked = append(marked, member)
+	}
+	return marked
+}